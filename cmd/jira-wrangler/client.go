@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira/v2/onpremise"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	"github.com/thetechnick/jira-wrangler/internal/cli"
+	jirainternal "github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+var errRecordAndReplay = errors.New("--record and --replay are mutually exclusive")
+
+// Load reads secrets and the config file used by commands querying JIRA.
+func (o *Options) Load() (*cli.Config, *time.Location, error) {
+	if err := o.LoadSecrets(); err != nil {
+		return nil, nil, fmt.Errorf("loading secrets from %q: %w", o.SecretsPath, err)
+	}
+
+	return loadConfig(o.ConfigPath)
+}
+
+// loadConfig loads the config file and its time zone.
+func loadConfig(path string) (*cli.Config, *time.Location, error) {
+	cfg, err := cli.LoadConfig(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	loc, err := cfg.Dates.Location()
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	return cfg, loc, nil
+}
+
+// newJiraClient sets up the JIRA client registering
+// request metrics with reg unless it is nil.
+func newJiraClient(
+	cmd *cobra.Command, opts Options, cfg *cli.Config, loc *time.Location, reg prometheus.Registerer,
+) (*jirainternal.Client, error) {
+	if opts.RecordDir != "" && opts.ReplayDir != "" {
+		return nil, errRecordAndReplay
+	}
+
+	maxIssuesPolicy, err := jirainternal.ParseMaxIssuesPolicy(opts.JiraMaxIssuesPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := jira.BearerAuthTransport{
+		Token: opts.JiraToken,
+	}
+	clientOpts := []jirainternal.ClientOption{
+		jirainternal.WithBaseURL(opts.JiraURL),
+		jirainternal.WithPageSize(opts.JiraPageSize),
+		jirainternal.WithMaxIssues(opts.JiraMaxIssues),
+		jirainternal.WithMaxIssuesPolicy(maxIssuesPolicy),
+		jirainternal.WithWorkers(opts.JiraWorkers),
+		jirainternal.WithRequestsPerSecond(opts.JiraRequestsPerSecond),
+		jirainternal.WithRequestBurst(opts.JiraRequestBurst),
+		jirainternal.WithFields(cfg.Fields),
+		jirainternal.WithExtraFields(cfg.ExtraFields),
+		jirainternal.WithLocation{Location: loc},
+		jirainternal.WithHistoryWindow(cfg.HistoryWindow()),
+		jirainternal.WithColorScale(cfg.ColorScale()),
+		jirainternal.WithWarningHandler(func(w jirainternal.Warning) {
+			fmt.Fprintln(cmd.ErrOrStderr(), "warning:", w)
+		}),
+	}
+
+	if opts.JiraRetryAttempts > 1 {
+		clientOpts = append(clientOpts, jirainternal.WithRetry{
+			MaxAttempts: opts.JiraRetryAttempts,
+		})
+	}
+
+	if reg != nil {
+		clientOpts = append(clientOpts, jirainternal.WithMetrics{Registerer: reg})
+	}
+
+	if opts.RecordDir != "" {
+		clientOpts = append(clientOpts, jirainternal.WithRecordDir(opts.RecordDir))
+	}
+
+	if opts.ReplayDir != "" {
+		clientOpts = append(clientOpts, jirainternal.WithReplayDir(opts.ReplayDir))
+	}
+
+	if opts.DryRun {
+		clientOpts = append(clientOpts, jirainternal.WithDryRun{Writer: cmd.OutOrStdout()})
+	}
+
+	client, err := jirainternal.NewClient(tp.Client(), clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("setting up JIRA client: %w", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/thetechnick/jira-wrangler/internal/cli"
+)
+
+var errInvalidQueries = errors.New("some queries are invalid")
+
+func newConfigCommand(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the config file",
+	}
+
+	cmd.AddCommand(newConfigValidateCommand(opts))
+
+	return cmd
+}
+
+func newConfigValidateCommand(opts *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the config file and test every query against JIRA",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := cli.CheckConfig(opts.ConfigPath); err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			cfg, loc, err := opts.Load()
+			if err != nil {
+				return err
+			}
+
+			client, err := newJiraClient(cmd, *opts, cfg, loc, nil)
+			if err != nil {
+				return err
+			}
+
+			if err := client.ResolveFields(cmd.Context()); err != nil {
+				return fmt.Errorf("resolving JIRA fields: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			failed := false
+
+			for _, reportCfg := range cfg.Reports {
+				for _, jql := range []string{reportCfg.Query(), reportCfg.CompletedQuery()} {
+					if jql == "" {
+						continue
+					}
+
+					total, err := client.CountIssues(cmd.Context(), jql)
+					if err != nil {
+						failed = true
+
+						fmt.Fprintf(out, "FAIL %s: %s\n     %v\n", reportCfg.ReportName(), jql, err)
+
+						continue
+					}
+
+					fmt.Fprintf(out, "ok   %s: %s (%d issues)\n", reportCfg.ReportName(), jql, total)
+				}
+			}
+
+			if failed {
+				return errInvalidQueries
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thetechnick/jira-wrangler/internal/cli"
+)
+
+func newFieldsCommand(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fields",
+		Short: "Inspect the fields known to JIRA",
+	}
+
+	cmd.AddCommand(newFieldsListCommand(opts))
+
+	return cmd
+}
+
+func newFieldsListCommand(opts *Options) *cobra.Command {
+	var customOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List field IDs and names to fill in the 'fields' mapping",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// Listing fields does not depend on the config
+			// as it is meant to help writing it.
+			if err := opts.LoadSecrets(); err != nil {
+				return fmt.Errorf("loading secrets from %q: %w", opts.SecretsPath, err)
+			}
+
+			client, err := newJiraClient(cmd, *opts, &cli.Config{}, time.UTC, nil)
+			if err != nil {
+				return err
+			}
+
+			fields, err := client.ListFields(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tTYPE")
+
+			for _, f := range fields {
+				if customOnly && !f.Custom {
+					continue
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\n", f.ID, f.Name, f.Type)
+			}
+
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&customOnly,
+		"custom",
+		customOnly,
+		"Only list custom fields",
+	)
+
+	return cmd
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/pflag"
 )
@@ -14,6 +17,25 @@ type Options struct {
 	ConfigPath            string
 	OverrideTemplatesPath string
 	SecretsPath           string
+	JiraPageSize          int
+	JiraMaxIssues         int
+	JiraMaxIssuesPolicy   string
+	JiraWorkers           int
+	JiraRequestsPerSecond float64
+	JiraRequestBurst      int
+	JiraRetryAttempts     int
+	Format                string
+	SnapshotDir           string
+	CompareSnapshot       bool
+	SlackWebhookURL       string
+	SMTPUsername          string
+	SMTPPassword          string
+	ConfluenceToken       string
+	DryRun                bool
+	PushgatewayURL        string
+	PushgatewayJob        string
+	RecordDir             string
+	ReplayDir             string
 }
 
 func (o *Options) AddFlags(flags *pflag.FlagSet) {
@@ -47,9 +69,147 @@ func (o *Options) AddFlags(flags *pflag.FlagSet) {
 		o.SecretsPath,
 		"Path to directory containing secrets",
 	)
+	flags.IntVar(
+		&o.JiraPageSize,
+		"jira-page-size",
+		o.JiraPageSize,
+		"Number of issues requested per JIRA search page",
+	)
+	flags.IntVar(
+		&o.JiraMaxIssues,
+		"jira-max-issues",
+		o.JiraMaxIssues,
+		"Maximum number of issues fetched per report (0 means unlimited)",
+	)
+	flags.StringVar(
+		&o.JiraMaxIssuesPolicy,
+		"jira-max-issues-policy",
+		o.JiraMaxIssuesPolicy,
+		"What to do when a report exceeds --jira-max-issues: 'warn' or 'fail'",
+	)
+	flags.IntVar(
+		&o.JiraWorkers,
+		"jira-workers",
+		o.JiraWorkers,
+		"Maximum number of concurrent JIRA requests",
+	)
+	flags.Float64Var(
+		&o.JiraRequestsPerSecond,
+		"jira-requests-per-second",
+		o.JiraRequestsPerSecond,
+		"Maximum rate of JIRA requests (0 disables rate limiting)",
+	)
+	flags.IntVar(
+		&o.JiraRequestBurst,
+		"jira-request-burst",
+		o.JiraRequestBurst,
+		"Number of JIRA requests allowed to exceed the rate limit momentarily",
+	)
+	flags.IntVar(
+		&o.JiraRetryAttempts,
+		"jira-retry-attempts",
+		o.JiraRetryAttempts,
+		"Maximum number of attempts for idempotent JIRA requests failing transiently (1 disables retries)",
+	)
+	flags.StringVar(
+		&o.RecordDir,
+		"record",
+		o.RecordDir,
+		"Directory to save all JIRA responses in for use with --replay",
+	)
+	flags.StringVar(
+		&o.ReplayDir,
+		"replay",
+		o.ReplayDir,
+		"Directory to serve JIRA responses saved with --record from instead of querying JIRA",
+	)
+}
+
+// AddReportFlags adds the flags configuring where and how
+// reports are written by the report command.
+func (o *Options) AddReportFlags(flags *pflag.FlagSet) {
+	o.addFormatFlag(flags)
+	flags.StringVar(
+		&o.SnapshotDir,
+		"snapshot-dir",
+		o.SnapshotDir,
+		"Directory to store a snapshot of every report in",
+	)
+	flags.BoolVar(
+		&o.CompareSnapshot,
+		"compare-snapshot",
+		o.CompareSnapshot,
+		"Include changes since the latest snapshot in --snapshot-dir",
+	)
+	flags.StringVar(
+		&o.SlackWebhookURL,
+		"slack-webhook-url",
+		o.SlackWebhookURL,
+		"Slack incoming webhook URL to post the report to",
+	)
+	flags.StringVar(
+		&o.SMTPUsername,
+		"smtp-username",
+		o.SMTPUsername,
+		"Username to authenticate to the SMTP server with",
+	)
+	flags.StringVar(
+		&o.SMTPPassword,
+		"smtp-password",
+		o.SMTPPassword,
+		"Password to authenticate to the SMTP server with",
+	)
+	flags.StringVar(
+		&o.ConfluenceToken,
+		"confluence-token",
+		o.ConfluenceToken,
+		"Confluence Personal Access Token",
+	)
+	flags.BoolVar(
+		&o.DryRun,
+		"dry-run",
+		o.DryRun,
+		"Print requests archiving the report in JIRA instead of sending them",
+	)
+	flags.StringVar(
+		&o.PushgatewayURL,
+		"pushgateway-url",
+		o.PushgatewayURL,
+		"Pushgateway to push metrics of the run to",
+	)
+	flags.StringVar(
+		&o.PushgatewayJob,
+		"pushgateway-job",
+		o.PushgatewayJob,
+		"Job name metrics are pushed under",
+	)
+}
+
+func (o *Options) addFormatFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Format,
+		"format",
+		o.Format,
+		"Output format: 'text', 'markdown', 'html', 'confluence', 'jira', 'json' or 'yaml'",
+	)
 }
 
 func (o *Options) LoadSecrets() error {
+	// Replaying neither needs to reach nor authenticate to JIRA,
+	// the URL is only used to link issues if it is available.
+	if o.ReplayDir != "" {
+		if o.JiraURL == "" {
+			url, err := loadOptionalFromFile(filepath.Join(o.SecretsPath, "jira-url"))
+			if err != nil {
+				return fmt.Errorf("loading 'jira-url' from file: %w", err)
+			}
+
+			o.JiraURL = url
+		}
+
+		return o.loadOptionalSecrets()
+	}
+
 	if o.JiraURL == "" {
 		url, err := loadFromFile(filepath.Join(o.SecretsPath, "jira-url"))
 		if err != nil {
@@ -68,9 +228,59 @@ func (o *Options) LoadSecrets() error {
 		o.JiraToken = token
 	}
 
+	return o.loadOptionalSecrets()
+}
+
+func (o *Options) loadOptionalSecrets() error {
+	if o.SlackWebhookURL == "" {
+		url, err := loadOptionalFromFile(filepath.Join(o.SecretsPath, "slack-webhook-url"))
+		if err != nil {
+			return fmt.Errorf("loading 'slack-webhook-url' from file: %w", err)
+		}
+
+		o.SlackWebhookURL = strings.TrimSpace(url)
+	}
+
+	if o.SMTPUsername == "" {
+		username, err := loadOptionalFromFile(filepath.Join(o.SecretsPath, "smtp-username"))
+		if err != nil {
+			return fmt.Errorf("loading 'smtp-username' from file: %w", err)
+		}
+
+		o.SMTPUsername = strings.TrimSpace(username)
+	}
+
+	if o.SMTPPassword == "" {
+		password, err := loadOptionalFromFile(filepath.Join(o.SecretsPath, "smtp-password"))
+		if err != nil {
+			return fmt.Errorf("loading 'smtp-password' from file: %w", err)
+		}
+
+		o.SMTPPassword = strings.TrimSuffix(password, "\n")
+	}
+
+	if o.ConfluenceToken == "" {
+		token, err := loadOptionalFromFile(filepath.Join(o.SecretsPath, "confluence-token"))
+		if err != nil {
+			return fmt.Errorf("loading 'confluence-token' from file: %w", err)
+		}
+
+		o.ConfluenceToken = strings.TrimSpace(token)
+	}
+
 	return nil
 }
 
+// loadOptionalFromFile returns an empty string if the file does not exist.
+func loadOptionalFromFile(path string) (string, error) {
+	data, err := loadFromFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+
+	return data, err
+}
+
 func loadFromFile(path string) (string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
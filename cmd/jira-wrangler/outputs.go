@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/cli"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+// newOutputWriter returns a writer rendering the report into all outputs.
+// Renderings are buffered and only written to their destinations once
+// all of them succeeded so that a failing template never leaves an
+// inconsistent set of files behind.
+func newOutputWriter(
+	stdout io.Writer, outputs []cli.OutputConfig, jiraURL string,
+	dates cli.DateConfig, loc *time.Location, colors jira.ColorScale,
+) (*outputWriter, error) {
+	w := &outputWriter{
+		stdout:  stdout,
+		outputs: outputs,
+		buffers: make([]*bytes.Buffer, 0, len(outputs)),
+	}
+
+	for i, out := range outputs {
+		format, err := cli.ParseFormat(out.Format)
+		if err != nil {
+			return nil, err
+		}
+
+		buf := &bytes.Buffer{}
+
+		var rw cli.ReportWriter
+		if format.Structured() {
+			rw = cli.NewStructuredReportWriter(
+				buf,
+				cli.WithFormat(format),
+				cli.WithJiraURL(jiraURL),
+				cli.WithColorScale(colors),
+			)
+		} else {
+			rw, err = cli.NewTemplatedReportWriter(
+				buf,
+				cli.WithFormat(format),
+				cli.WithJiraURL(jiraURL),
+				cli.WithOverrideTemplatePath(out.Templates),
+				cli.WithDateLayout(dates.DateLayout()),
+				cli.WithLocation{Location: loc},
+				cli.WithColorScale(colors),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("initializing output %d: %w", i, err)
+			}
+		}
+
+		w.buffers = append(w.buffers, buf)
+		w.writers = append(w.writers, rw)
+	}
+
+	return w, nil
+}
+
+type outputWriter struct {
+	stdout  io.Writer
+	outputs []cli.OutputConfig
+	buffers []*bytes.Buffer
+	writers cli.MultiReportWriter
+}
+
+func (w *outputWriter) WriteReport(rpt cli.Report) error {
+	if err := w.writers.WriteReport(rpt); err != nil {
+		return err
+	}
+
+	for i, out := range w.outputs {
+		if out.Stdout() {
+			if _, err := w.buffers[i].WriteTo(w.stdout); err != nil {
+				return fmt.Errorf("writing output %d to stdout: %w", i, err)
+			}
+
+			continue
+		}
+
+		if err := os.WriteFile(out.Path, w.buffers[i].Bytes(), 0o644); err != nil {
+			return fmt.Errorf("writing output %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira/v2/onpremise"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	"github.com/thetechnick/jira-wrangler/internal/cli"
+	"github.com/thetechnick/jira-wrangler/internal/confluence"
+)
+
+func newReportCommand(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Query JIRA and write the configured reports",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			var (
+				reg     prometheus.Registerer
+				metrics *cli.ReportMetrics
+			)
+
+			if opts.PushgatewayURL != "" {
+				r := prometheus.NewRegistry()
+				if metrics, err = cli.NewReportMetrics(r); err != nil {
+					return err
+				}
+
+				reg = r
+				start := time.Now()
+
+				defer func() {
+					metrics.ObserveRun(start, err)
+
+					pushErr := cli.PushMetrics(opts.PushgatewayURL, opts.PushgatewayJob, r, err == nil)
+					if pushErr == nil {
+						return
+					}
+
+					if err == nil {
+						err = pushErr
+					} else {
+						fmt.Fprintln(cmd.ErrOrStderr(), "warning:", pushErr)
+					}
+				}()
+			}
+
+			cfg, loc, err := opts.Load()
+			if err != nil {
+				return err
+			}
+
+			if opts.ReplayDir != "" {
+				if err := cfg.ValidateReplay(opts.SlackWebhookURL); err != nil {
+					return err
+				}
+			}
+
+			format, err := cli.ParseFormat(opts.Format)
+			if err != nil {
+				return err
+			}
+
+			client, err := newJiraClient(cmd, *opts, cfg, loc, reg)
+			if err != nil {
+				return err
+			}
+
+			if err := client.ResolveFields(ctx); err != nil {
+				return fmt.Errorf("resolving JIRA fields: %w", err)
+			}
+
+			groups := make([]cli.Group, 0, len(cfg.Reports))
+
+			for _, reportCfg := range cfg.Reports {
+				group, err := cli.BuildGroup(ctx, client, reportCfg, cfg.ColorScale())
+				if err != nil {
+					return err
+				}
+
+				groups = append(groups, group)
+			}
+
+			// Only count issues once all queries succeeded so that a
+			// failed run never pushes counts of a subset of reports.
+			if metrics != nil {
+				for i, reportCfg := range cfg.Reports {
+					metrics.ObserveGroup(reportCfg.ReportName(), groups[i])
+				}
+			}
+
+			outputs := cfg.Outputs
+			if len(outputs) == 0 {
+				outputs = []cli.OutputConfig{{
+					Format:    string(format),
+					Templates: opts.OverrideTemplatesPath,
+				}}
+			}
+
+			outputWriter, err := newOutputWriter(
+				cmd.OutOrStdout(), outputs, opts.JiraURL, cfg.Dates, loc, cfg.ColorScale(),
+			)
+			if err != nil {
+				return fmt.Errorf("initializing report writer: %w", err)
+			}
+
+			rw := cli.NewMultiReportWriter(outputWriter)
+
+			if opts.SlackWebhookURL != "" {
+				rw = append(rw, cli.NewSlackReportWriter(
+					&http.Client{Timeout: 30 * time.Second},
+					opts.SlackWebhookURL,
+					cli.WithJiraURL(opts.JiraURL),
+					cli.WithColorScale(cfg.ColorScale()),
+				))
+			}
+
+			if cfg.Email != nil {
+				email, err := cli.NewEmailReportWriter(
+					*cfg.Email,
+					cli.WithSMTPAuth{Username: opts.SMTPUsername, Password: opts.SMTPPassword},
+					cli.WithJiraURL(opts.JiraURL),
+					cli.WithOverrideTemplatePath(cfg.Email.Templates),
+					cli.WithDateLayout(cfg.Dates.DateLayout()),
+					cli.WithLocation{Location: loc},
+					cli.WithColorScale(cfg.ColorScale()),
+				)
+				if err != nil {
+					return fmt.Errorf("initializing email: %w", err)
+				}
+
+				rw = append(rw, email)
+			}
+
+			if cfg.Confluence != nil {
+				if opts.ConfluenceToken == "" {
+					return errors.New("publishing to confluence requires a 'confluence-token'")
+				}
+
+				tp := jira.BearerAuthTransport{
+					Token: opts.ConfluenceToken,
+				}
+
+				publisher, err := confluence.NewClient(
+					tp.Client(),
+					confluence.WithBaseURL(cfg.Confluence.URL),
+				)
+				if err != nil {
+					return fmt.Errorf("initializing confluence client: %w", err)
+				}
+
+				page, err := cli.NewConfluenceReportWriter(
+					publisher,
+					*cfg.Confluence,
+					cli.WithJiraURL(opts.JiraURL),
+					cli.WithDateLayout(cfg.Dates.DateLayout()),
+					cli.WithLocation{Location: loc},
+					cli.WithColorScale(cfg.ColorScale()),
+				)
+				if err != nil {
+					return fmt.Errorf("initializing confluence: %w", err)
+				}
+
+				rw = append(rw, page)
+			}
+
+			if cfg.JiraArchive != nil {
+				archive, err := cli.NewJiraReportWriter(
+					client,
+					*cfg.JiraArchive,
+					cli.WithJiraURL(opts.JiraURL),
+					cli.WithDateLayout(cfg.Dates.DateLayout()),
+					cli.WithLocation{Location: loc},
+					cli.WithColorScale(cfg.ColorScale()),
+				)
+				if err != nil {
+					return fmt.Errorf("initializing jira archive: %w", err)
+				}
+
+				rw = append(rw, archive)
+			}
+
+			rpt := cli.NewReport(cfg.Title, groups...)
+
+			var snapshots *cli.SnapshotStore
+			if opts.CompareSnapshot && opts.SnapshotDir == "" {
+				return errors.New("--compare-snapshot requires --snapshot-dir")
+			} else if opts.SnapshotDir != "" {
+				snapshots = cli.NewSnapshotStore(opts.SnapshotDir)
+			}
+
+			if snapshots != nil && opts.CompareSnapshot {
+				prev, err := snapshots.Latest()
+				if err != nil {
+					return fmt.Errorf("loading previous snapshot: %w", err)
+				}
+
+				if prev != nil {
+					cli.ApplyChanges(&rpt, *prev)
+				}
+			}
+
+			if err := rw.WriteReport(rpt); err != nil {
+				return fmt.Errorf("writing report header: %w", err)
+			}
+
+			if snapshots != nil {
+				if err := snapshots.Save(rpt, time.Now()); err != nil {
+					return fmt.Errorf("saving snapshot: %w", err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	opts.AddReportFlags(cmd.Flags())
+
+	return cmd
+}
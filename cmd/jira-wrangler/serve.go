@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+	"github.com/thetechnick/jira-wrangler/internal/cli"
+)
+
+type ServeOptions struct {
+	ListenAddress string
+	CacheTTL      time.Duration
+}
+
+func newServeCommand(opts *Options) *cobra.Command {
+	serveOpts := ServeOptions{
+		ListenAddress: ":8080",
+		CacheTTL:      5 * time.Minute,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve reports over HTTP, rendered on demand",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			cfg, loc, err := opts.Load()
+			if err != nil {
+				return err
+			}
+
+			reg := prometheus.NewRegistry()
+			reg.MustRegister(
+				collectors.NewGoCollector(),
+				collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			)
+
+			metrics, err := cli.NewReportMetrics(reg)
+			if err != nil {
+				return err
+			}
+
+			client, err := newJiraClient(cmd, *opts, cfg, loc, reg)
+			if err != nil {
+				return err
+			}
+
+			srv := cli.NewServer(
+				cfg,
+				cli.NewCachedSearcher(client, serveOpts.CacheTTL),
+				cli.WithJiraURL(opts.JiraURL),
+				cli.WithOverrideTemplatePath(opts.OverrideTemplatesPath),
+				cli.WithDateLayout(cfg.Dates.DateLayout()),
+				cli.WithLocation{Location: loc},
+				cli.WithColorScale(cfg.ColorScale()),
+				cli.WithMetrics{ReportMetrics: metrics},
+			)
+
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+			mux.Handle("/", srv.Handler())
+
+			go func() {
+				// Keep trying so that the server becomes ready
+				// once JIRA is reachable instead of exiting.
+				for delay := time.Second; ; delay = minDuration(2*delay, time.Minute) {
+					err := client.ResolveFields(ctx)
+					if err == nil {
+						srv.MarkReady()
+
+						return
+					}
+
+					fmt.Fprintln(cmd.ErrOrStderr(), "resolving JIRA fields:", err)
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(delay):
+					}
+				}
+			}()
+
+			return serve(ctx, &http.Server{
+				Addr:              serveOpts.ListenAddress,
+				Handler:           mux,
+				ReadHeaderTimeout: 10 * time.Second,
+			})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(
+		&serveOpts.ListenAddress,
+		"listen-address",
+		serveOpts.ListenAddress,
+		"Address to serve reports on",
+	)
+	flags.DurationVar(
+		&serveOpts.CacheTTL,
+		"cache-ttl",
+		serveOpts.CacheTTL,
+		"Duration JIRA search results are cached for",
+	)
+
+	return cmd
+}
+
+// serve runs the server until ctx is cancelled
+// and then waits for in-flight requests.
+func serve(ctx context.Context, srv *http.Server) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("serving: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutting down: %w", err)
+	}
+
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving: %w", err)
+	}
+
+	return nil
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+
+	return b
+}
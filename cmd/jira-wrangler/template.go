@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thetechnick/jira-wrangler/internal/cli"
+)
+
+func newTemplateCommand(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Work on report templates",
+	}
+
+	cmd.AddCommand(newTemplatePreviewCommand(opts))
+
+	return cmd
+}
+
+func newTemplatePreviewCommand(opts *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preview <fixture>",
+		Short: "Render a report saved as snapshot, JSON report or JSON fixture without querying JIRA",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, loc, err := loadPreviewConfig(cmd, opts.ConfigPath)
+			if err != nil {
+				return err
+			}
+
+			rpt, err := cli.LoadReportFixture(args[0])
+			if err != nil {
+				return err
+			}
+
+			w, err := newOutputWriter(cmd.OutOrStdout(), []cli.OutputConfig{{
+				Format:    opts.Format,
+				Templates: opts.OverrideTemplatesPath,
+			}}, opts.JiraURL, cfg.Dates, loc, cfg.ColorScale())
+			if err != nil {
+				return fmt.Errorf("initializing report writer: %w", err)
+			}
+
+			return w.WriteReport(rpt)
+		},
+	}
+
+	opts.addFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+// loadPreviewConfig loads the colors and date settings used for
+// rendering. The config file is optional unless given explicitly.
+func loadPreviewConfig(cmd *cobra.Command, path string) (*cli.Config, *time.Location, error) {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) && !cmd.Flag("config-file").Changed {
+		return &cli.Config{}, time.UTC, nil
+	}
+
+	return loadConfig(path)
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+// Changes describes how a group changed since a previous report.
+type Changes struct {
+	// Since is the time the previous report was taken at.
+	Since string
+	// Added lists issues which were not part of the previous report.
+	Added []jira.Issue
+	// Removed lists issues which are no longer part of the
+	// report, e.g. because they were closed or relabeled.
+	Removed []jira.Issue
+	// ColorChanges lists issues whose color changed.
+	ColorChanges []IssueChange
+	// TargetEndSlips lists issues whose target end moved later.
+	TargetEndSlips []IssueChange
+	// NewComments lists issues with a new status comment.
+	NewComments []IssueChange
+}
+
+// IssueChange pairs the previous and current state of an issue.
+type IssueChange struct {
+	Issue    jira.Issue
+	Previous jira.Issue
+}
+
+// Empty reports whether nothing changed.
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 &&
+		len(c.ColorChanges) == 0 && len(c.TargetEndSlips) == 0 &&
+		len(c.NewComments) == 0
+}
+
+// ApplyChanges sets Changes on every group of rpt by comparing
+// it to the group with the same title in prev. Groups without
+// a counterpart in prev are left untouched.
+func ApplyChanges(rpt *Report, prev Snapshot) {
+	prevGroups := make(map[string]Group, len(prev.Report.Groups))
+	for _, g := range prev.Report.Groups {
+		prevGroups[g.Title] = g
+	}
+
+	since := prev.TakenAt.Format("02 Jan 06 15:04 MST")
+
+	for i, g := range rpt.Groups {
+		p, ok := prevGroups[g.Title]
+		if !ok {
+			continue
+		}
+
+		changes := diffIssues(p.Issues, g.Issues)
+		changes.Since = since
+
+		rpt.Groups[i].Changes = &changes
+	}
+}
+
+func diffIssues(prev, cur []jira.Issue) Changes {
+	var changes Changes
+
+	prevByKey := make(map[string]jira.Issue, len(prev))
+	for _, issue := range prev {
+		prevByKey[issue.Key] = issue
+	}
+
+	curKeys := make(map[string]struct{}, len(cur))
+
+	for _, issue := range cur {
+		curKeys[issue.Key] = struct{}{}
+
+		p, ok := prevByKey[issue.Key]
+		if !ok {
+			changes.Added = append(changes.Added, issue)
+
+			continue
+		}
+
+		change := IssueChange{Issue: issue, Previous: p}
+
+		if p.Color != issue.Color {
+			changes.ColorChanges = append(changes.ColorChanges, change)
+		}
+
+		if targetEndSlipped(p, issue) {
+			changes.TargetEndSlips = append(changes.TargetEndSlips, change)
+		}
+
+		if issue.StatusComment != "" && issue.StatusComment != p.StatusComment {
+			changes.NewComments = append(changes.NewComments, change)
+		}
+	}
+
+	for _, issue := range prev {
+		if _, ok := curKeys[issue.Key]; !ok {
+			changes.Removed = append(changes.Removed, issue)
+		}
+	}
+
+	return changes
+}
+
+// targetEndSlipped reports whether the target end moved
+// later or was removed. Unparsable raw values are
+// compared textually.
+func targetEndSlipped(prev, cur jira.Issue) bool {
+	if prev.TargetEndDate.IsZero() || cur.TargetEndDate.IsZero() {
+		return prev.TargetEnd != cur.TargetEnd && prev.TargetEnd != ""
+	}
+
+	return cur.TargetEndDate.After(prev.TargetEndDate)
+}
@@ -3,15 +3,42 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/mail"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
+	"unicode"
 
+	"github.com/thetechnick/jira-wrangler/internal/jira"
 	"sigs.k8s.io/yaml"
 )
 
 var ErrUnknownFileType = errors.New("unknown file type")
 
+// CheckConfig loads the config file like LoadConfig but also
+// rejects keys which do not correspond to any setting, e.g. typos.
+func CheckConfig(path string) (*Config, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file %q: %w", path, err)
+	}
+
+	if err := yaml.UnmarshalStrict(data, &Config{}); err != nil {
+		return nil, fmt.Errorf("checking config: %w", err)
+	}
+
+	return config, nil
+}
+
 func LoadConfig(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -29,15 +56,612 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, ErrUnknownFileType
 	}
 
+	if _, err := config.Dates.Location(); err != nil {
+		return nil, fmt.Errorf("validating dates: %w", err)
+	}
+
+	if err := config.Colors.Validate(); err != nil {
+		return nil, fmt.Errorf("validating colors: %w", err)
+	}
+
+	for name, field := range config.Fields {
+		if err := field.Validate(); err != nil {
+			return nil, fmt.Errorf("validating field %q: %w", name, err)
+		}
+	}
+
+	paths := map[string]struct{}{}
+
+	for i, out := range config.Outputs {
+		if err := out.Validate(); err != nil {
+			return nil, fmt.Errorf("validating output %d: %w", i, err)
+		}
+
+		if out.Stdout() {
+			continue
+		}
+
+		if _, ok := paths[out.Path]; ok {
+			return nil, fmt.Errorf("validating output %d: %w: %q", i, ErrDuplicateOutputPath, out.Path)
+		}
+
+		paths[out.Path] = struct{}{}
+	}
+
+	if config.Confluence != nil {
+		if err := config.Confluence.Validate(); err != nil {
+			return nil, fmt.Errorf("validating confluence: %w", err)
+		}
+	}
+
+	if config.JiraArchive != nil {
+		if err := config.JiraArchive.Validate(); err != nil {
+			return nil, fmt.Errorf("validating jiraArchive: %w", err)
+		}
+	}
+
+	if config.Email != nil {
+		if err := config.Email.Validate(); err != nil {
+			return nil, fmt.Errorf("validating email: %w", err)
+		}
+	}
+
+	names := map[string]struct{}{}
+
+	for i, rpt := range config.Reports {
+		if err := rpt.Validate(); err != nil {
+			return nil, fmt.Errorf("validating report %d (%q): %w", i, rpt.Title, err)
+		}
+
+		if _, ok := names[rpt.ReportName()]; ok {
+			return nil, fmt.Errorf("validating report %d (%q): %w: %q", i, rpt.Title, ErrDuplicateReportName, rpt.ReportName())
+		}
+
+		names[rpt.ReportName()] = struct{}{}
+	}
+
 	return &config, nil
 }
 
 type Config struct {
-	Title   string         `json:"title"`
-	Reports []ReportConfig `json:"reports"`
+	Title string `json:"title"`
+	// Fields maps logical field names such as "color" and
+	// "targetEnd" to JIRA field IDs or display names.
+	Fields map[string]jira.FieldRef `json:"fields,omitempty"`
+	// ExtraFields lists additional fields fetched for every
+	// issue and exposed to templates as .Fields.<name>.
+	ExtraFields []string `json:"extraFields,omitempty"`
+	// Colors defines the values of the color field in the
+	// order they are sorted in. Defaults to Red, Yellow, Green.
+	Colors jira.ColorScale `json:"colors,omitempty"`
+	// Dates configures how dates are rendered.
+	Dates DateConfig `json:"dates,omitempty"`
+	// HistoryDays enables fetching issue changelogs and includes
+	// changes of the last given number of days in Issue.History.
+	HistoryDays int `json:"historyDays,omitempty"`
+	// Outputs lists the renderings written by a single run.
+	// Defaults to writing --format to stdout.
+	Outputs []OutputConfig `json:"outputs,omitempty"`
+	// Email optionally sends the report via SMTP.
+	Email *EmailConfig `json:"email,omitempty"`
+	// Confluence optionally publishes the report as a page.
+	Confluence *ConfluenceConfig `json:"confluence,omitempty"`
+	// JiraArchive optionally posts the report back into JIRA.
+	JiraArchive *JiraArchiveConfig `json:"jiraArchive,omitempty"`
+	Reports     []ReportConfig     `json:"reports"`
+}
+
+const DefaultDateLayout = "2006-01-02"
+
+var ErrPublishingReplay = errors.New("replayed reports can only be written to outputs")
+
+// ValidateReplay rejects publishing reports rendered from recorded
+// responses so that offline runs never reach real Slack channels,
+// mailboxes, Confluence spaces or JIRA projects. slackWebhookURL
+// is the webhook configured outside of the config file, if any.
+func (c Config) ValidateReplay(slackWebhookURL string) error {
+	var sinks []string
+
+	if slackWebhookURL != "" {
+		sinks = append(sinks, "slack")
+	}
+
+	if c.Email != nil {
+		sinks = append(sinks, "email")
+	}
+
+	if c.Confluence != nil {
+		sinks = append(sinks, "confluence")
+	}
+
+	if c.JiraArchive != nil {
+		sinks = append(sinks, "jiraArchive")
+	}
+
+	if len(sinks) > 0 {
+		return fmt.Errorf("%w, remove %s", ErrPublishingReplay, strings.Join(sinks, ", "))
+	}
+
+	return nil
 }
 
+// ColorScale returns Colors or the default scale if unset.
+func (c Config) ColorScale() jira.ColorScale {
+	if len(c.Colors) == 0 {
+		return jira.DefaultColorScale()
+	}
+
+	return c.Colors
+}
+
+// HistoryWindow returns the period changes are included in Issue.History for.
+func (c Config) HistoryWindow() time.Duration {
+	return time.Duration(c.HistoryDays) * 24 * time.Hour
+}
+
+type DateConfig struct {
+	// Layout is a Go time layout. Defaults to "2006-01-02".
+	Layout string `json:"layout,omitempty"`
+	// Timezone is an IANA time zone name. Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+func (c DateConfig) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("loading timezone %q: %w", c.Timezone, err)
+	}
+
+	return loc, nil
+}
+
+func (c DateConfig) DateLayout() string {
+	if c.Layout == "" {
+		return DefaultDateLayout
+	}
+
+	return c.Layout
+}
+
+var (
+	ErrDuplicateOutputPath   = errors.New("duplicate output path")
+	ErrTemplatesNotSupported = errors.New("'templates' is not supported by structured formats")
+)
+
+// OutputConfig configures one rendering of the report.
+type OutputConfig struct {
+	// Format is one of "text", "markdown", "html", "confluence",
+	// "jira", "json" or "yaml".
+	Format string `json:"format"`
+	// Path is the file to write to. Empty or "-" writes to stdout.
+	Path string `json:"path,omitempty"`
+	// Templates is a directory of templates overriding
+	// the built-in template set of the format.
+	Templates string `json:"templates,omitempty"`
+}
+
+func (c OutputConfig) Validate() error {
+	format, err := ParseFormat(c.Format)
+	if err != nil {
+		return err
+	}
+
+	if format.Structured() && c.Templates != "" {
+		return ErrTemplatesNotSupported
+	}
+
+	return nil
+}
+
+// Stdout reports whether the output is written to stdout.
+func (c OutputConfig) Stdout() bool {
+	return c.Path == "" || c.Path == "-"
+}
+
+const (
+	DefaultSMTPPort     = 587
+	DefaultEmailSubject = "{{ .Title }} - Week {{ .WeekOfYear }}"
+)
+
+var (
+	ErrEmailHostRequired       = errors.New("'host' is required")
+	ErrEmailFromRequired       = errors.New("'from' is required")
+	ErrEmailRecipientsRequired = errors.New("'to' requires at least one address")
+)
+
+// EmailConfig configures sending the report via SMTP. Credentials
+// are read from the 'smtp-username' and 'smtp-password' secrets.
+type EmailConfig struct {
+	// Host is the SMTP server to send through. STARTTLS is required.
+	Host string `json:"host"`
+	// Port defaults to 587.
+	Port int      `json:"port,omitempty"`
+	From string   `json:"from"`
+	To   []string `json:"to"`
+	Cc   []string `json:"cc,omitempty"`
+	// Subject is a template rendered with the report.
+	// Defaults to "{{ .Title }} - Week {{ .WeekOfYear }}".
+	Subject string `json:"subject,omitempty"`
+	// Templates is a directory whose "text" and "html"
+	// subdirectories override the templates of either part.
+	Templates string `json:"templates,omitempty"`
+}
+
+func (c EmailConfig) Validate() error {
+	if c.Host == "" {
+		return ErrEmailHostRequired
+	}
+
+	if c.From == "" {
+		return ErrEmailFromRequired
+	}
+
+	if len(c.To) == 0 {
+		return ErrEmailRecipientsRequired
+	}
+
+	for _, addr := range append([]string{c.From}, append(c.To, c.Cc...)...) {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fmt.Errorf("parsing address %q: %w", addr, err)
+		}
+	}
+
+	if _, err := c.SubjectTemplate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Addr returns the host:port pair of the SMTP server.
+func (c EmailConfig) Addr() string {
+	port := c.Port
+	if port == 0 {
+		port = DefaultSMTPPort
+	}
+
+	return net.JoinHostPort(c.Host, strconv.Itoa(port))
+}
+
+func (c EmailConfig) SubjectTemplate() (*template.Template, error) {
+	subject := c.Subject
+	if subject == "" {
+		subject = DefaultEmailSubject
+	}
+
+	tmpl, err := template.New("subject").Parse(subject)
+	if err != nil {
+		return nil, fmt.Errorf("parsing subject: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+const DefaultJiraArchiveSummary = "Weekly Status - Week {{ .WeekOfYear }}"
+
+var ErrJiraArchiveTarget = errors.New("exactly one of 'project' or 'issue' is required")
+
+// JiraArchiveConfig configures archiving the report in JIRA
+// either as a new issue per run or as a comment on an issue.
+type JiraArchiveConfig struct {
+	// Project to create an issue in for every report.
+	Project string `json:"project,omitempty"`
+	// IssueType of created issues. Defaults to "Task".
+	IssueType string `json:"issueType,omitempty"`
+	// Summary is a template rendered with the report.
+	// Defaults to "Weekly Status - Week {{ .WeekOfYear }}".
+	Summary string `json:"summary,omitempty"`
+	// Labels are added to created issues.
+	Labels []string `json:"labels,omitempty"`
+	// Issue is the key of a tracking issue the report
+	// is added to as a comment.
+	Issue string `json:"issue,omitempty"`
+}
+
+func (c JiraArchiveConfig) Validate() error {
+	if (c.Project == "") == (c.Issue == "") {
+		return ErrJiraArchiveTarget
+	}
+
+	if _, err := c.SummaryTemplate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c JiraArchiveConfig) SummaryTemplate() (*template.Template, error) {
+	summary := c.Summary
+	if summary == "" {
+		summary = DefaultJiraArchiveSummary
+	}
+
+	tmpl, err := template.New("summary").Parse(summary)
+	if err != nil {
+		return nil, fmt.Errorf("parsing summary: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+type ConfluencePageMode string
+
+const (
+	// ConfluencePageWeekly creates a new page every week.
+	ConfluencePageWeekly ConfluencePageMode = "weekly"
+	// ConfluencePageRolling replaces the content of a single page.
+	ConfluencePageRolling ConfluencePageMode = "rolling"
+)
+
+const (
+	DefaultConfluenceWeeklyTitle  = "{{ .Title }} - {{ .ISOYear }} Week {{ .WeekOfYear }}"
+	DefaultConfluenceRollingTitle = "{{ .Title }}"
+)
+
+var (
+	ErrConfluenceURLRequired   = errors.New("'url' is required")
+	ErrConfluenceSpaceRequired = errors.New("'space' is required")
+	ErrUnknownConfluencePage   = errors.New("'page' must be 'weekly' or 'rolling'")
+)
+
+// ConfluenceConfig configures publishing the report to Confluence.
+// The token is read from the 'confluence-token' secret.
+type ConfluenceConfig struct {
+	URL   string `json:"url"`
+	Space string `json:"space"`
+	// ParentID is the ID of the page new pages are created below.
+	ParentID string `json:"parentID,omitempty"`
+	// Page is either "weekly" or "rolling". Defaults to "weekly".
+	Page ConfluencePageMode `json:"page,omitempty"`
+	// Title is a template rendered with the report. Pages are
+	// updated if a page with the same title exists in the space.
+	Title string `json:"title,omitempty"`
+}
+
+func (c ConfluenceConfig) Validate() error {
+	if c.URL == "" {
+		return ErrConfluenceURLRequired
+	}
+
+	if c.Space == "" {
+		return ErrConfluenceSpaceRequired
+	}
+
+	switch c.Page {
+	case "", ConfluencePageWeekly, ConfluencePageRolling:
+	default:
+		return ErrUnknownConfluencePage
+	}
+
+	if _, err := c.TitleTemplate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c ConfluenceConfig) TitleTemplate() (*template.Template, error) {
+	title := c.Title
+
+	switch {
+	case title != "":
+	case c.Page == ConfluencePageRolling:
+		title = DefaultConfluenceRollingTitle
+	default:
+		title = DefaultConfluenceWeeklyTitle
+	}
+
+	tmpl, err := template.New("title").Parse(title)
+	if err != nil {
+		return nil, fmt.Errorf("parsing title: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+const (
+	DefaultProject = "SDE"
+	DefaultOrderBy = "priority DESC"
+)
+
+var DefaultStatuses = []string{"New", "To Do", "In Progress"}
+
+var ErrDuplicateReportName = errors.New("duplicate report name")
+
+var ErrCompletedQueryRequired = errors.New("'completed.jql' is required when 'jql' is set")
+
+var ErrConflictingQuery = errors.New("'jql' cannot be combined with 'project', 'statuses', 'label', 'labels', 'components' or 'orderBy'")
+
 type ReportConfig struct {
 	Title string `json:"title"`
-	Label string `json:"label"`
+	// Name identifies the report in URLs of the serve
+	// command. Defaults to the lowercased title with
+	// runs of other characters replaced by dashes.
+	Name string `json:"name,omitempty"`
+	// JQL is a complete JQL query used verbatim.
+	// Mutually exclusive with the structured query fields below.
+	JQL string `json:"jql,omitempty"`
+	// Project to select issues from. Defaults to "SDE".
+	Project string `json:"project,omitempty"`
+	// Statuses to select issues in. Defaults to "New", "To Do" and "In Progress".
+	Statuses []string `json:"statuses,omitempty"`
+	// Label selects issues carrying the given label.
+	Label string `json:"label,omitempty"`
+	// Labels selects issues carrying any of the given labels.
+	Labels []string `json:"labels,omitempty"`
+	// Components selects issues belonging to any of the given components.
+	Components []string `json:"components,omitempty"`
+	// OrderBy is the JQL ORDER BY clause. Defaults to "priority DESC".
+	OrderBy string `json:"orderBy,omitempty"`
+	// SortBy lists the keys issues are sorted by on top
+	// of the query order. Defaults to sorting by color.
+	SortBy []SortKey `json:"sortBy,omitempty"`
+	// GroupBy optionally splits the report section into sub-groups.
+	GroupBy GroupBy `json:"groupBy,omitempty"`
+	// Completed optionally lists recently resolved issues.
+	Completed *CompletedConfig `json:"completed,omitempty"`
+}
+
+func (c ReportConfig) Validate() error {
+	if err := c.GroupBy.Validate(); err != nil {
+		return err
+	}
+
+	if c.JQL == "" {
+		return nil
+	}
+
+	if c.Completed != nil && c.Completed.JQL == "" {
+		return ErrCompletedQueryRequired
+	}
+
+	if c.Project != "" || len(c.Statuses) > 0 || c.Label != "" ||
+		len(c.Labels) > 0 || len(c.Components) > 0 || c.OrderBy != "" {
+		return ErrConflictingQuery
+	}
+
+	return nil
+}
+
+// ReportName returns the name of the report.
+func (c ReportConfig) ReportName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+
+	var (
+		b    strings.Builder
+		dash bool
+	)
+
+	for _, r := range strings.ToLower(c.Title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+
+			b.WriteRune(r)
+			dash = false
+
+			continue
+		}
+
+		dash = true
+	}
+
+	return b.String()
+}
+
+// SortKeys returns the keys issues of this report are sorted by.
+func (c ReportConfig) SortKeys() []SortKey {
+	if len(c.SortBy) == 0 {
+		return DefaultSortBy
+	}
+
+	return c.SortBy
+}
+
+// Query returns the JQL query selecting the issues of this report.
+func (c ReportConfig) Query() string {
+	if c.JQL != "" {
+		return c.JQL
+	}
+
+	statuses := c.Statuses
+	if len(statuses) == 0 {
+		statuses = DefaultStatuses
+	}
+
+	clauses := append(c.selectionClauses(), "Status in "+listJQL(statuses))
+
+	orderBy := c.OrderBy
+	if orderBy == "" {
+		orderBy = DefaultOrderBy
+	}
+
+	return strings.Join(clauses, " AND ") + " ORDER BY " + orderBy
+}
+
+// CompletedQuery returns the JQL query selecting issues of this report
+// resolved within the completed window or an empty string if the
+// completed section is disabled.
+func (c ReportConfig) CompletedQuery() string {
+	if c.Completed == nil {
+		return ""
+	}
+
+	if c.Completed.JQL != "" {
+		return c.Completed.JQL
+	}
+
+	clauses := append(c.selectionClauses(), fmt.Sprintf("resolved >= -%dd", c.Completed.WindowDays()))
+
+	return strings.Join(clauses, " AND ") + " ORDER BY resolved DESC"
+}
+
+// selectionClauses returns the JQL clauses shared
+// by the open and completed issue queries.
+func (c ReportConfig) selectionClauses() []string {
+	project := c.Project
+	if project == "" {
+		project = DefaultProject
+	}
+
+	clauses := []string{
+		"project = " + quoteJQL(project),
+	}
+
+	if c.Label != "" {
+		clauses = append(clauses, "labels = "+quoteJQL(c.Label))
+	}
+
+	if len(c.Labels) > 0 {
+		clauses = append(clauses, "labels in "+listJQL(c.Labels))
+	}
+
+	if len(c.Components) > 0 {
+		clauses = append(clauses, "component in "+listJQL(c.Components))
+	}
+
+	return clauses
+}
+
+const DefaultCompletedDays = 7
+
+// CompletedConfig enables a section listing
+// recently resolved issues below the open work.
+type CompletedConfig struct {
+	// Days is the number of days resolved issues are included for.
+	// Defaults to 7.
+	Days int `json:"days,omitempty"`
+	// JQL overrides the generated query. Required
+	// if the report itself is configured via 'jql'.
+	JQL string `json:"jql,omitempty"`
+}
+
+func (c CompletedConfig) WindowDays() int {
+	if c.Days <= 0 {
+		return DefaultCompletedDays
+	}
+
+	return c.Days
+}
+
+var _jqlEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func quoteJQL(s string) string {
+	return `"` + _jqlEscaper.Replace(s) + `"`
+}
+
+func listJQL(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, quoteJQL(v))
+	}
+
+	return "(" + strings.Join(quoted, ",") + ")"
 }
@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportConfig_Query(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Config   ReportConfig
+		Expected string
+	}{
+		"label only": {
+			Config: ReportConfig{
+				Label: "mtsre+cssre-apac",
+			},
+			Expected: `project = "SDE" AND labels = "mtsre+cssre-apac" AND Status in ("New","To Do","In Progress") ORDER BY priority DESC`,
+		},
+		"raw jql": {
+			Config: ReportConfig{
+				JQL: `project = "MTSRE" ORDER BY key`,
+			},
+			Expected: `project = "MTSRE" ORDER BY key`,
+		},
+		"structured": {
+			Config: ReportConfig{
+				Project:    "MTSRE",
+				Statuses:   []string{"In Progress"},
+				Labels:     []string{"a", "b"},
+				Components: []string{"Addons"},
+				OrderBy:    "updated DESC",
+			},
+			Expected: `project = "MTSRE" AND labels in ("a","b") AND component in ("Addons") AND Status in ("In Progress") ORDER BY updated DESC`,
+		},
+		"escaping": {
+			Config: ReportConfig{
+				Label: `quo"te\back`,
+			},
+			Expected: `project = "SDE" AND labels = "quo\"te\\back" AND Status in ("New","To Do","In Progress") ORDER BY priority DESC`,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, tc.Config.Query())
+		})
+	}
+}
+
+func TestReportConfig_CompletedQuery(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Config   ReportConfig
+		Expected string
+	}{
+		"disabled": {
+			Config: ReportConfig{Label: "a"},
+		},
+		"default window": {
+			Config: ReportConfig{
+				Label:     "a",
+				Statuses:  []string{"In Progress"},
+				Completed: &CompletedConfig{},
+			},
+			Expected: `project = "SDE" AND labels = "a" AND resolved >= -7d ORDER BY resolved DESC`,
+		},
+		"custom window": {
+			Config: ReportConfig{
+				Project:   "MTSRE",
+				Completed: &CompletedConfig{Days: 14},
+			},
+			Expected: `project = "MTSRE" AND resolved >= -14d ORDER BY resolved DESC`,
+		},
+		"raw jql": {
+			Config: ReportConfig{
+				JQL:       "project = SDE",
+				Completed: &CompletedConfig{JQL: "project = SDE AND resolved >= -1w"},
+			},
+			Expected: "project = SDE AND resolved >= -1w",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, tc.Config.CompletedQuery())
+		})
+	}
+}
+
+func TestReportConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, ReportConfig{Label: "a"}.Validate())
+	assert.NoError(t, ReportConfig{JQL: "project = SDE"}.Validate())
+	assert.ErrorIs(t, ReportConfig{JQL: "project = SDE", Label: "a"}.Validate(), ErrConflictingQuery)
+	assert.ErrorIs(t, ReportConfig{JQL: "project = SDE", Completed: &CompletedConfig{}}.Validate(), ErrCompletedQueryRequired)
+}
+
+func TestOutputConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, OutputConfig{Format: "markdown", Path: "report.md", Templates: "templates"}.Validate())
+	assert.NoError(t, OutputConfig{Format: "json"}.Validate())
+	assert.ErrorIs(t, OutputConfig{Format: "pdf"}.Validate(), ErrUnknownFormat)
+	assert.ErrorIs(t, OutputConfig{Format: "yaml", Templates: "templates"}.Validate(), ErrTemplatesNotSupported)
+}
+
+func TestEmailConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := EmailConfig{
+		Host: "smtp.example.com",
+		From: "Status Bot <bot@example.com>",
+		To:   []string{"team@example.com"},
+	}
+
+	assert.NoError(t, valid.Validate())
+	assert.Equal(t, "smtp.example.com:587", valid.Addr())
+
+	for name, tc := range map[string]struct {
+		Modify   func(c *EmailConfig)
+		Expected error
+	}{
+		"missing host": {
+			Modify:   func(c *EmailConfig) { c.Host = "" },
+			Expected: ErrEmailHostRequired,
+		},
+		"missing from": {
+			Modify:   func(c *EmailConfig) { c.From = "" },
+			Expected: ErrEmailFromRequired,
+		},
+		"missing recipients": {
+			Modify:   func(c *EmailConfig) { c.To = nil },
+			Expected: ErrEmailRecipientsRequired,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := valid
+			tc.Modify(&cfg)
+
+			assert.ErrorIs(t, cfg.Validate(), tc.Expected)
+		})
+	}
+
+	assert.Error(t, EmailConfig{Host: "h", From: "f@example.com", To: []string{"not an address"}}.Validate())
+	assert.Error(t, EmailConfig{Host: "h", From: "f@example.com", To: []string{"t@example.com"}, Subject: "{{ .Title"}.Validate())
+}
+
+func TestConfluenceConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, ConfluenceConfig{URL: "https://confluence.example.com", Space: "SDE"}.Validate())
+	assert.ErrorIs(t, ConfluenceConfig{Space: "SDE"}.Validate(), ErrConfluenceURLRequired)
+	assert.ErrorIs(t, ConfluenceConfig{URL: "https://confluence.example.com"}.Validate(), ErrConfluenceSpaceRequired)
+	assert.ErrorIs(t, ConfluenceConfig{
+		URL: "https://confluence.example.com", Space: "SDE", Page: "daily",
+	}.Validate(), ErrUnknownConfluencePage)
+}
+
+func TestJiraArchiveConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, JiraArchiveConfig{Project: "SDE"}.Validate())
+	assert.NoError(t, JiraArchiveConfig{Issue: "SDE-1"}.Validate())
+	assert.ErrorIs(t, JiraArchiveConfig{}.Validate(), ErrJiraArchiveTarget)
+	assert.ErrorIs(t, JiraArchiveConfig{Project: "SDE", Issue: "SDE-1"}.Validate(), ErrJiraArchiveTarget)
+}
+
+func TestConfig_ValidateReplay(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Config{Outputs: []OutputConfig{{Format: "html"}}}.ValidateReplay(""))
+
+	err := Config{
+		Email:       &EmailConfig{},
+		JiraArchive: &JiraArchiveConfig{},
+	}.ValidateReplay("https://hooks.slack.com/services/x")
+	require.ErrorIs(t, err, ErrPublishingReplay)
+	assert.Contains(t, err.Error(), "slack, email, jiraArchive")
+
+	assert.ErrorIs(t, Config{Confluence: &ConfluenceConfig{}}.ValidateReplay(""), ErrPublishingReplay)
+}
+
+func TestReportConfig_ReportName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "nasa", ReportConfig{Title: "NASA"}.ReportName())
+	assert.Equal(t, "rosa-hcp-q3", ReportConfig{Title: " ROSA / HCP (Q3) "}.ReportName())
+	assert.Equal(t, "custom", ReportConfig{Title: "NASA", Name: "custom"}.ReportName())
+}
+
+func TestCheckConfig(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Config        string
+		ExpectedError string
+	}{
+		"valid": {
+			Config: "title: Status\nreports:\n- title: NASA\n  label: nasa\n",
+		},
+		"unknown key": {
+			Config:        "title: Status\nreports:\n- title: NASA\n  lable: nasa\n",
+			ExpectedError: `unknown field "lable"`,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.Config), 0o644))
+
+			_, err := CheckConfig(path)
+			if tc.ExpectedError != "" {
+				require.ErrorContains(t, err, tc.ExpectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/confluence"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+// PagePublisher is implemented by confluence.Client.
+type PagePublisher interface {
+	PublishPage(ctx context.Context, page confluence.Page) (string, error)
+}
+
+func NewConfluenceReportWriter(
+	publisher PagePublisher, conf ConfluenceConfig, opts ...ConfluenceReportWriterOption,
+) (*ConfluenceReportWriter, error) {
+	var cfg ConfluenceReportWriterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	title, err := conf.TitleTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	rendering := []TemplatedReportWriterOption{
+		WithFormat(FormatConfluence),
+		WithJiraURL(cfg.JiraURL),
+		WithDateLayout(cfg.DateLayout),
+		WithLocation{Location: cfg.Location},
+		WithColorScale(cfg.Colors),
+	}
+
+	if err := validateRendering(rendering, FormatConfluence); err != nil {
+		return nil, err
+	}
+
+	return &ConfluenceReportWriter{
+		cfg:       cfg,
+		conf:      conf,
+		publisher: publisher,
+		title:     title,
+		rendering: rendering,
+	}, nil
+}
+
+// ConfluenceReportWriter publishes reports as Confluence pages
+// rendered from the confluence template set.
+type ConfluenceReportWriter struct {
+	cfg       ConfluenceReportWriterConfig
+	conf      ConfluenceConfig
+	publisher PagePublisher
+	title     *template.Template
+	rendering []TemplatedReportWriterOption
+}
+
+func (w *ConfluenceReportWriter) WriteReport(rpt Report) error {
+	var title strings.Builder
+	if err := w.title.Execute(&title, rpt); err != nil {
+		return fmt.Errorf("rendering page title: %w", err)
+	}
+
+	var body bytes.Buffer
+
+	rw, err := NewTemplatedReportWriter(&body, w.rendering...)
+	if err != nil {
+		return err
+	}
+
+	if err := rw.WriteReport(rpt); err != nil {
+		return fmt.Errorf("rendering page: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.Timeout)
+	defer cancel()
+
+	if _, err := w.publisher.PublishPage(ctx, confluence.Page{
+		SpaceKey: w.conf.Space,
+		ParentID: w.conf.ParentID,
+		Title:    strings.TrimSpace(title.String()),
+		Body:     body.String(),
+	}); err != nil {
+		return fmt.Errorf("publishing to confluence: %w", err)
+	}
+
+	return nil
+}
+
+type ConfluenceReportWriterConfig struct {
+	// Timeout limits publishing a single report. Defaults to 1m.
+	Timeout time.Duration
+	// JiraURL, DateLayout, Location and Colors are used
+	// to render the page body from the confluence templates.
+	JiraURL    string
+	DateLayout string
+	Location   *time.Location
+	Colors     jira.ColorScale
+}
+
+func (c *ConfluenceReportWriterConfig) Option(opts ...ConfluenceReportWriterOption) {
+	for _, opt := range opts {
+		opt.ConfigureConfluenceReportWriter(c)
+	}
+}
+
+func (c *ConfluenceReportWriterConfig) Default() {
+	if c.Timeout <= 0 {
+		c.Timeout = time.Minute
+	}
+
+	if c.DateLayout == "" {
+		c.DateLayout = DefaultDateLayout
+	}
+
+	if c.Location == nil {
+		c.Location = time.UTC
+	}
+}
+
+type ConfluenceReportWriterOption interface {
+	ConfigureConfluenceReportWriter(*ConfluenceReportWriterConfig)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/confluence"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestConfluenceReportWriter_WriteReport(t *testing.T) {
+	t.Parallel()
+
+	rpt := Report{
+		Title:       "Status",
+		WeekOfYear:  "1",
+		Now:         "02 Jan 23 12:42 UTC",
+		GeneratedAt: time.Date(2023, time.January, 2, 12, 42, 0, 0, time.UTC),
+		Groups: []Group{
+			{
+				Title: "group",
+				Issues: []jira.Issue{
+					{Key: "SDE-1", Color: jira.ColorRed, Summary: "a <b> c", Status: "New"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Config        ConfluenceConfig
+		ExpectedTitle string
+	}{
+		"weekly": {
+			Config:        ConfluenceConfig{Space: "SDE", ParentID: "1"},
+			ExpectedTitle: "Status - 2023 Week 1",
+		},
+		"rolling": {
+			Config:        ConfluenceConfig{Space: "SDE", Page: ConfluencePageRolling},
+			ExpectedTitle: "Status",
+		},
+		"custom title": {
+			Config:        ConfluenceConfig{Space: "SDE", Title: "Week {{ .WeekOfYear }}"},
+			ExpectedTitle: "Week 1",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var publisher fakePublisher
+
+			rw, err := NewConfluenceReportWriter(&publisher, tc.Config, WithJiraURL("https://jira.example.com"))
+			require.NoError(t, err)
+
+			require.NoError(t, rw.WriteReport(rpt))
+
+			require.Len(t, publisher.Pages, 1)
+
+			page := publisher.Pages[0]
+			assert.Equal(t, tc.Config.Space, page.SpaceKey)
+			assert.Equal(t, tc.Config.ParentID, page.ParentID)
+			assert.Equal(t, tc.ExpectedTitle, page.Title)
+			assert.Contains(t, page.Body, `<tr><td><ac:structured-macro ac:name="status">`+
+				`<ac:parameter ac:name="colour">Red</ac:parameter><ac:parameter ac:name="title">Red</ac:parameter>`+
+				`</ac:structured-macro></td><td><a href="https://jira.example.com/browse/SDE-1">SDE-1</a></td>`+
+				`<td>a &lt;b&gt; c</td><td>New</td><td></td><td></td></tr>`)
+		})
+	}
+}
+
+type fakePublisher struct {
+	Pages []confluence.Page
+}
+
+func (p *fakePublisher) PublishPage(_ context.Context, page confluence.Page) (string, error) {
+	p.Pages = append(p.Pages, page)
+
+	return "1", nil
+}
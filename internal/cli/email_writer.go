@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+var ErrStartTLSUnsupported = errors.New("smtp server does not support STARTTLS")
+
+func NewEmailReportWriter(email EmailConfig, opts ...EmailReportWriterOption) (*EmailReportWriter, error) {
+	var cfg EmailReportWriterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	subject, err := email.SubjectTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	rendering := map[Format][]TemplatedReportWriterOption{}
+
+	for _, format := range []Format{FormatText, FormatHTML} {
+		opts, err := emailRendering(cfg, format)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validateRendering(opts, format); err != nil {
+			return nil, err
+		}
+
+		rendering[format] = opts
+	}
+
+	return &EmailReportWriter{
+		cfg:       cfg,
+		email:     email,
+		subject:   subject,
+		rendering: rendering,
+	}, nil
+}
+
+// EmailReportWriter sends reports as multipart emails containing
+// both the text and the HTML rendering of the report.
+type EmailReportWriter struct {
+	cfg       EmailReportWriterConfig
+	email     EmailConfig
+	subject   *template.Template
+	rendering map[Format][]TemplatedReportWriterOption
+}
+
+func (w *EmailReportWriter) WriteReport(rpt Report) error {
+	msg, err := w.message(rpt)
+	if err != nil {
+		return fmt.Errorf("composing email: %w", err)
+	}
+
+	if err := w.send(msg); err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+
+	return nil
+}
+
+func (w *EmailReportWriter) message(rpt Report) ([]byte, error) {
+	var subject strings.Builder
+	if err := w.subject.Execute(&subject, rpt); err != nil {
+		return nil, fmt.Errorf("rendering subject: %w", err)
+	}
+
+	var (
+		msg  bytes.Buffer
+		body bytes.Buffer
+	)
+
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		Format      Format
+		ContentType string
+	}{
+		// Clients display the last part they support.
+		{Format: FormatText, ContentType: "text/plain; charset=utf-8"},
+		{Format: FormatHTML, ContentType: "text/html; charset=utf-8"},
+	} {
+		pw, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.ContentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(pw)
+
+		rw, err := NewTemplatedReportWriter(qp, w.rendering[part.Format]...)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := rw.WriteReport(rpt); err != nil {
+			return nil, fmt.Errorf("rendering %s: %w", part.Format, err)
+		}
+
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	from, err := formatAddresses(w.email.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := formatAddresses(w.email.To...)
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := formatAddresses(w.email.Cc...)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := []struct{ Key, Value string }{
+		{"From", from},
+		{"To", to},
+		{"Cc", cc},
+		{"Subject", mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject.String()))},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	}
+
+	for _, h := range headers {
+		if h.Value == "" {
+			continue
+		}
+
+		fmt.Fprintf(&msg, "%s: %s\r\n", h.Key, h.Value)
+	}
+
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+func (w *EmailReportWriter) send(msg []byte) error {
+	conn, err := net.DialTimeout("tcp", w.email.Addr(), w.cfg.Timeout)
+	if err != nil {
+		return fmt.Errorf("connecting to %q: %w", w.email.Addr(), err)
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(w.cfg.Timeout)); err != nil {
+		conn.Close()
+
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, w.email.Host)
+	if err != nil {
+		conn.Close()
+
+		return err
+	}
+
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); !ok {
+		return ErrStartTLSUnsupported
+	}
+
+	tlsConfig := w.cfg.TLSConfig.Clone()
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = w.email.Host
+	}
+
+	if err := c.StartTLS(tlsConfig); err != nil {
+		return fmt.Errorf("starting TLS: %w", err)
+	}
+
+	if w.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", w.cfg.Username, w.cfg.Password, w.email.Host)); err != nil {
+			return fmt.Errorf("authenticating: %w", err)
+		}
+	}
+
+	if err := c.Mail(address(w.email.From)); err != nil {
+		return err
+	}
+
+	for _, rcpt := range append(append([]string{}, w.email.To...), w.email.Cc...) {
+		if err := c.Rcpt(address(rcpt)); err != nil {
+			return fmt.Errorf("adding recipient %q: %w", rcpt, err)
+		}
+	}
+
+	data, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := data.Write(msg); err != nil {
+		return err
+	}
+
+	if err := data.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// address strips the display name from addresses
+// like "Jane Doe <jane@example.com>" which are
+// validated when loading the config.
+func address(addr string) string {
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil {
+		return addr
+	}
+
+	return parsed.Address
+}
+
+// emailRendering returns the options rendering the given part.
+// Override templates are looked up in the subdirectory of
+// OverrideTemplatePath named after the format, if it exists.
+func emailRendering(cfg EmailReportWriterConfig, format Format) ([]TemplatedReportWriterOption, error) {
+	opts := []TemplatedReportWriterOption{
+		WithFormat(format),
+		WithJiraURL(cfg.JiraURL),
+		WithDateLayout(cfg.DateLayout),
+		WithLocation{Location: cfg.Location},
+		WithColorScale(cfg.Colors),
+	}
+
+	if cfg.OverrideTemplatePath == "" {
+		return opts, nil
+	}
+
+	dir := filepath.Join(cfg.OverrideTemplatePath, string(format))
+
+	switch info, err := os.Stat(dir); {
+	case errors.Is(err, fs.ErrNotExist):
+		return opts, nil
+	case err != nil:
+		return nil, fmt.Errorf("loading %s override templates: %w", format, err)
+	case info.IsDir():
+		opts = append(opts, WithOverrideTemplatePath(dir))
+	}
+
+	return opts, nil
+}
+
+// formatAddresses parses the given addresses and joins them
+// for use in a header, encoding non-ASCII display names.
+func formatAddresses(addrs ...string) (string, error) {
+	formatted := make([]string, 0, len(addrs))
+
+	for _, addr := range addrs {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return "", fmt.Errorf("parsing address %q: %w", addr, err)
+		}
+
+		formatted = append(formatted, parsed.String())
+	}
+
+	return strings.Join(formatted, ", "), nil
+}
+
+type EmailReportWriterConfig struct {
+	// Username and Password authenticate via SMTP AUTH PLAIN if set.
+	Username string
+	Password string
+	// TLSConfig is used for STARTTLS.
+	TLSConfig *tls.Config
+	// Timeout limits the whole SMTP conversation. Defaults to 1m.
+	Timeout time.Duration
+	// JiraURL, DateLayout, Location and Colors apply
+	// to both the text and the HTML part of the email.
+	JiraURL    string
+	DateLayout string
+	Location   *time.Location
+	Colors     jira.ColorScale
+	// OverrideTemplatePath may contain "text" and "html"
+	// directories overriding the templates of either part.
+	OverrideTemplatePath string
+}
+
+func (c *EmailReportWriterConfig) Option(opts ...EmailReportWriterOption) {
+	for _, opt := range opts {
+		opt.ConfigureEmailReportWriter(c)
+	}
+}
+
+func (c *EmailReportWriterConfig) Default() {
+	if c.TLSConfig == nil {
+		c.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = time.Minute
+	}
+
+	if c.DateLayout == "" {
+		c.DateLayout = DefaultDateLayout
+	}
+
+	if c.Location == nil {
+		c.Location = time.UTC
+	}
+}
+
+type EmailReportWriterOption interface {
+	ConfigureEmailReportWriter(*EmailReportWriterConfig)
+}
@@ -0,0 +1,321 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestEmailReportWriter_WriteReport(t *testing.T) {
+	t.Parallel()
+
+	srv := newFakeSMTP(t, true)
+
+	rw, err := NewEmailReportWriter(
+		EmailConfig{
+			Host: "127.0.0.1",
+			Port: srv.Port(),
+			From: "Status Böt <bot@example.com>",
+			To:   []string{"team@example.com"},
+			Cc:   []string{"Boss <boss@example.com>"},
+		},
+		WithSMTPAuth{Username: "user", Password: "pass"},
+		WithTLSConfig{Config: srv.ClientTLSConfig()},
+		WithJiraURL("https://jira.example.com"),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, rw.WriteReport(Report{
+		Title:      "Status Update",
+		WeekOfYear: "4",
+		Groups: []Group{
+			{
+				Title: "group",
+				Issues: []jira.Issue{
+					{Key: "SDE-1", Color: jira.ColorRed, Summary: "a <b> c"},
+				},
+			},
+		},
+	}))
+
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	assert.Equal(t, "\x00user\x00pass", srv.auth)
+	assert.Equal(t, "bot@example.com", srv.from)
+	assert.Equal(t, []string{"team@example.com", "boss@example.com"}, srv.rcpts)
+
+	msg, err := mail.ReadMessage(strings.NewReader(srv.data))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Status Update - Week 4", msg.Header.Get("Subject"))
+	assert.Equal(t, "<team@example.com>", msg.Header.Get("To"))
+	assert.Equal(t, `"Boss" <boss@example.com>`, msg.Header.Get("Cc"))
+	assert.Equal(t, "=?utf-8?q?Status_B=C3=B6t?= <bot@example.com>", msg.Header.Get("From"))
+
+	from, err := msg.Header.AddressList("From")
+	require.NoError(t, err)
+	assert.Equal(t, []*mail.Address{{Name: "Status Böt", Address: "bot@example.com"}}, from)
+
+	parts := readEmailParts(t, msg)
+
+	assert.Contains(t, parts["text/plain; charset=utf-8"], "SDE-1")
+	assert.Contains(t, parts["text/html; charset=utf-8"],
+		`<a href="https://jira.example.com/browse/SDE-1">SDE-1</a> a &lt;b&gt; c`)
+}
+
+func TestEmailReportWriter_OverrideTemplates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "text"), 0o700))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "text", "report.tmpl"), []byte(`{{ define "report" }}custom {{ .Title }}{{ end }}`), 0o600,
+	))
+
+	rw, err := NewEmailReportWriter(
+		EmailConfig{From: "bot@example.com", To: []string{"team@example.com"}},
+		WithOverrideTemplatePath(dir),
+	)
+	require.NoError(t, err)
+
+	data, err := rw.message(Report{Title: "Status Update"})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	parts := readEmailParts(t, msg)
+
+	assert.Equal(t, "custom Status Update", parts["text/plain; charset=utf-8"])
+
+	html := parts["text/html; charset=utf-8"]
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"), html)
+	assert.Contains(t, html, "<h1>Status Update</h1>")
+	assert.NotContains(t, html, "custom")
+}
+
+// readEmailParts returns the decoded parts of
+// a multipart/alternative message by content type.
+func readEmailParts(t *testing.T, msg *mail.Message) map[string]string {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+
+		// Quoted-printable is decoded transparently.
+		body, err := io.ReadAll(p)
+		require.NoError(t, err)
+
+		parts[p.Header.Get("Content-Type")] = string(body)
+	}
+
+	return parts
+}
+
+func TestEmailReportWriter_WriteReport_NoStartTLS(t *testing.T) {
+	t.Parallel()
+
+	srv := newFakeSMTP(t, false)
+
+	rw, err := NewEmailReportWriter(EmailConfig{
+		Host: "127.0.0.1",
+		Port: srv.Port(),
+		From: "bot@example.com",
+		To:   []string{"team@example.com"},
+	})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, rw.WriteReport(Report{}), ErrStartTLSUnsupported)
+}
+
+// fakeSMTP implements just enough of SMTP to accept a single
+// message via STARTTLS and AUTH PLAIN.
+type fakeSMTP struct {
+	l        net.Listener
+	cert     *x509.Certificate
+	tls      *tls.Config
+	startTLS bool
+
+	lock  sync.Mutex
+	auth  string
+	from  string
+	rcpts []string
+	data  string
+}
+
+func newFakeSMTP(t *testing.T, startTLS bool) *fakeSMTP {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { l.Close() })
+
+	cert, key := selfSignedCert(t)
+
+	s := &fakeSMTP{
+		l:        l,
+		cert:     cert.Leaf,
+		startTLS: startTLS,
+		tls: &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: cert.Certificate, PrivateKey: key}},
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+
+	go s.serve()
+
+	return s
+}
+
+func (s *fakeSMTP) Port() int {
+	return s.l.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTP) ClientTLSConfig() *tls.Config {
+	pool := x509.NewCertPool()
+	pool.AddCert(s.cert)
+
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+}
+
+func (s *fakeSMTP) serve() {
+	conn, err := s.l.Accept()
+	if err != nil {
+		return
+	}
+
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(cmd) {
+		case "EHLO":
+			if s.startTLS {
+				_ = tp.PrintfLine("250-localhost")
+				_ = tp.PrintfLine("250-STARTTLS")
+			}
+
+			_ = tp.PrintfLine("250 AUTH PLAIN")
+		case "STARTTLS":
+			_ = tp.PrintfLine("220 ready")
+
+			tlsConn := tls.Server(conn, s.tls)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+
+			conn = tlsConn
+			tp = textproto.NewConn(conn)
+		case "AUTH":
+			_, encoded, _ := strings.Cut(arg, " ")
+			auth, _ := base64.StdEncoding.DecodeString(encoded)
+
+			s.lock.Lock()
+			s.auth = string(auth)
+			s.lock.Unlock()
+
+			_ = tp.PrintfLine("235 ok")
+		case "MAIL":
+			s.lock.Lock()
+			s.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			s.lock.Unlock()
+
+			_ = tp.PrintfLine("250 ok")
+		case "RCPT":
+			s.lock.Lock()
+			s.rcpts = append(s.rcpts, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			s.lock.Unlock()
+
+			_ = tp.PrintfLine("250 ok")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+
+			s.lock.Lock()
+			s.data = string(data)
+			s.lock.Unlock()
+
+			_ = tp.PrintfLine("250 ok")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+
+			return
+		default:
+			_ = tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func selfSignedCert(t *testing.T) (tls.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, Leaf: leaf}, key
+}
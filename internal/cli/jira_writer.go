@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+// IssuePublisher is implemented by jira.Client.
+type IssuePublisher interface {
+	CreateIssue(ctx context.Context, issue jira.NewIssue) (string, error)
+	AddComment(ctx context.Context, key, body string) error
+}
+
+func NewJiraReportWriter(
+	publisher IssuePublisher, archive JiraArchiveConfig, opts ...JiraReportWriterOption,
+) (*JiraReportWriter, error) {
+	var cfg JiraReportWriterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	summary, err := archive.SummaryTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	rendering := []TemplatedReportWriterOption{
+		WithFormat(FormatJiraWiki),
+		WithJiraURL(cfg.JiraURL),
+		WithDateLayout(cfg.DateLayout),
+		WithLocation{Location: cfg.Location},
+		WithColorScale(cfg.Colors),
+	}
+
+	if err := validateRendering(rendering, FormatJiraWiki); err != nil {
+		return nil, err
+	}
+
+	return &JiraReportWriter{
+		cfg:       cfg,
+		archive:   archive,
+		publisher: publisher,
+		summary:   summary,
+		rendering: rendering,
+	}, nil
+}
+
+// JiraReportWriter archives reports in JIRA rendered as wiki markup,
+// either as a new issue or as a comment on a tracking issue.
+type JiraReportWriter struct {
+	cfg       JiraReportWriterConfig
+	archive   JiraArchiveConfig
+	publisher IssuePublisher
+	summary   *template.Template
+	rendering []TemplatedReportWriterOption
+}
+
+func (w *JiraReportWriter) WriteReport(rpt Report) error {
+	var body bytes.Buffer
+
+	rw, err := NewTemplatedReportWriter(&body, w.rendering...)
+	if err != nil {
+		return err
+	}
+
+	if err := rw.WriteReport(rpt); err != nil {
+		return fmt.Errorf("rendering wiki markup: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.Timeout)
+	defer cancel()
+
+	if w.archive.Issue != "" {
+		return w.publisher.AddComment(ctx, w.archive.Issue, body.String())
+	}
+
+	var summary strings.Builder
+	if err := w.summary.Execute(&summary, rpt); err != nil {
+		return fmt.Errorf("rendering summary: %w", err)
+	}
+
+	_, err = w.publisher.CreateIssue(ctx, jira.NewIssue{
+		Project:     w.archive.Project,
+		Type:        w.archive.IssueType,
+		Summary:     strings.TrimSpace(summary.String()),
+		Description: body.String(),
+		Labels:      w.archive.Labels,
+	})
+
+	return err
+}
+
+type JiraReportWriterConfig struct {
+	// Timeout limits archiving a single report. Defaults to 1m.
+	Timeout time.Duration
+	// JiraURL, DateLayout, Location and Colors are used to
+	// render the archived wiki markup from the jira templates.
+	JiraURL    string
+	DateLayout string
+	Location   *time.Location
+	Colors     jira.ColorScale
+}
+
+func (c *JiraReportWriterConfig) Option(opts ...JiraReportWriterOption) {
+	for _, opt := range opts {
+		opt.ConfigureJiraReportWriter(c)
+	}
+}
+
+func (c *JiraReportWriterConfig) Default() {
+	if c.Timeout <= 0 {
+		c.Timeout = time.Minute
+	}
+
+	if c.DateLayout == "" {
+		c.DateLayout = DefaultDateLayout
+	}
+
+	if c.Location == nil {
+		c.Location = time.UTC
+	}
+}
+
+type JiraReportWriterOption interface {
+	ConfigureJiraReportWriter(*JiraReportWriterConfig)
+}
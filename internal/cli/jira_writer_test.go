@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestJiraReportWriter_WriteReport(t *testing.T) {
+	t.Parallel()
+
+	rpt := Report{
+		Title:      "Status",
+		WeekOfYear: "4",
+		Now:        "24 Jan 23 12:42 UTC",
+		Groups: []Group{
+			{
+				Title: "group",
+				Issues: []jira.Issue{
+					{
+						Key:           "SDE-1",
+						Color:         jira.ColorRed,
+						Summary:       `fix [prod] *now* in C:\tmp -not- +later+ ??maybe??`,
+						Status:        "In {Progress}",
+						Priority:      "P1 *urgent*",
+						StatusComment: "# blocked\non networking",
+					},
+				},
+				Completed: []jira.Issue{
+					{Key: "SDE-2", Summary: "done", Resolution: "Won't_Do"},
+				},
+			},
+		},
+	}
+
+	expectedBody := strings.Join([]string{
+		"h1. Status",
+		"",
+		"_Week 4 - 24 Jan 23 12:42 UTC_",
+		"",
+		"h2. group",
+		"",
+		"* {color:#d73a49}*Red*{color} [SDE-1|https://jira.example.com/browse/SDE-1] fix \\[prod\\] \\*now\\* in C:&#92;tmp \\-not\\- \\+later\\+ \\?\\?maybe\\?\\?",
+		"** Status: In \\{Progress\\}",
+		"** Priority: P1 \\*urgent\\*",
+		"** Comment: \\# blocked \\\\ on networking",
+		"",
+		"h3. Completed",
+		"",
+		"* [SDE-2|https://jira.example.com/browse/SDE-2] done (Won't\\_Do)",
+		"",
+	}, "\n")
+
+	for name, tc := range map[string]struct {
+		Archive          JiraArchiveConfig
+		ExpectedIssues   []jira.NewIssue
+		ExpectedComments []fakeComment
+	}{
+		"issue": {
+			Archive: JiraArchiveConfig{Project: "SDE", Labels: []string{"weekly-status"}},
+			ExpectedIssues: []jira.NewIssue{
+				{
+					Project:     "SDE",
+					Summary:     "Weekly Status - Week 4",
+					Description: expectedBody,
+					Labels:      []string{"weekly-status"},
+				},
+			},
+		},
+		"comment": {
+			Archive: JiraArchiveConfig{Issue: "SDE-100"},
+			ExpectedComments: []fakeComment{
+				{Key: "SDE-100", Body: expectedBody},
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var publisher fakeIssuePublisher
+
+			rw, err := NewJiraReportWriter(&publisher, tc.Archive, WithJiraURL("https://jira.example.com"))
+			require.NoError(t, err)
+
+			require.NoError(t, rw.WriteReport(rpt))
+
+			assert.Equal(t, tc.ExpectedIssues, publisher.Issues)
+			assert.Equal(t, tc.ExpectedComments, publisher.Comments)
+		})
+	}
+}
+
+type fakeComment struct {
+	Key  string
+	Body string
+}
+
+type fakeIssuePublisher struct {
+	Issues   []jira.NewIssue
+	Comments []fakeComment
+}
+
+func (p *fakeIssuePublisher) CreateIssue(_ context.Context, issue jira.NewIssue) (string, error) {
+	p.Issues = append(p.Issues, issue)
+
+	return "SDE-1", nil
+}
+
+func (p *fakeIssuePublisher) AddComment(_ context.Context, key, body string) error {
+	p.Comments = append(p.Comments, fakeComment{Key: key, Body: body})
+
+	return nil
+}
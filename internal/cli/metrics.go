@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+func NewReportMetrics(reg prometheus.Registerer) (*ReportMetrics, error) {
+	m := &ReportMetrics{
+		issues: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "jira_wrangler_issues",
+			Help: "Number of open issues in a report by color and status.",
+		}, []string{"report", "color", "status"}),
+		runDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "jira_wrangler_run_duration_seconds",
+			Help: "Duration of the last run generating reports.",
+		}),
+		// Without labels the vector is only exported after the
+		// first success. Pushing the metrics of a failed run
+		// therefore keeps the previous value in the Pushgateway.
+		lastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "jira_wrangler_last_success_timestamp_seconds",
+			Help: "Unix time of the last run generating reports successfully.",
+		}, nil),
+	}
+
+	for _, c := range []prometheus.Collector{m.issues, m.runDuration, m.lastSuccess} {
+		if err := reg.Register(c); err != nil {
+			return nil, fmt.Errorf("registering report metrics: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// ReportMetrics records the contents of reports and the
+// health of the runs generating them.
+type ReportMetrics struct {
+	issues      *prometheus.GaugeVec
+	runDuration prometheus.Gauge
+	lastSuccess *prometheus.GaugeVec
+}
+
+// ObserveGroup replaces the issue counts of the named report.
+func (m *ReportMetrics) ObserveGroup(name string, group Group) {
+	m.issues.DeletePartialMatch(prometheus.Labels{"report": name})
+
+	for _, issue := range group.Issues {
+		m.issues.WithLabelValues(name, issue.Color.String(), issue.Status).Inc()
+	}
+}
+
+// ObserveRun records the duration of a run started at start
+// and its completion time if it did not fail.
+func (m *ReportMetrics) ObserveRun(start time.Time, err error) {
+	now := time.Now()
+
+	m.runDuration.Set(now.Sub(start).Seconds())
+
+	if err == nil {
+		m.lastSuccess.WithLabelValues().Set(float64(now.Unix()))
+	}
+}
+
+// PushMetrics pushes the gathered metrics to a Pushgateway under the
+// given job. Successful runs replace all metrics of the job. Failed
+// runs only replace the metrics they gathered, keeping the last
+// success timestamp.
+func PushMetrics(url, job string, g prometheus.Gatherer, success bool) error {
+	pusher := push.New(url, job).Gatherer(g)
+
+	send := pusher.Add
+	if success {
+		send = pusher.Push
+	}
+
+	if err := send(); err != nil {
+		return fmt.Errorf("pushing metrics: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestReportMetrics_ObserveGroup(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+
+	m, err := NewReportMetrics(reg)
+	require.NoError(t, err)
+
+	m.ObserveGroup("apac", Group{Issues: []jira.Issue{
+		{Key: "SDE-1", Color: jira.ColorRed, Status: "In Progress"},
+		{Key: "SDE-2", Color: jira.ColorRed, Status: "In Progress"},
+		{Key: "SDE-3", Color: jira.ColorGreen, Status: "To Do"},
+	}})
+	m.ObserveGroup("emea", Group{Issues: []jira.Issue{
+		{Key: "SDE-4", Color: jira.ColorYellow, Status: "To Do"},
+	}})
+
+	// Issues no longer part of a report must not be counted anymore.
+	m.ObserveGroup("apac", Group{Issues: []jira.Issue{
+		{Key: "SDE-1", Color: jira.ColorRed, Status: "In Progress"},
+	}})
+
+	expected := `
+# HELP jira_wrangler_issues Number of open issues in a report by color and status.
+# TYPE jira_wrangler_issues gauge
+jira_wrangler_issues{color="Red",report="apac",status="In Progress"} 1
+jira_wrangler_issues{color="Yellow",report="emea",status="To Do"} 1
+`
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "jira_wrangler_issues"))
+}
+
+func TestReportMetrics_ObserveRun(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+
+	m, err := NewReportMetrics(reg)
+	require.NoError(t, err)
+
+	m.ObserveRun(time.Now().Add(-time.Second), errors.New("boom"))
+
+	assert.Equal(t, 0, testutil.CollectAndCount(reg, "jira_wrangler_last_success_timestamp_seconds"),
+		"failed runs do not export a last success")
+	assert.GreaterOrEqual(t, testutil.ToFloat64(m.runDuration), 1.0)
+
+	before := time.Now().Unix()
+
+	m.ObserveRun(time.Now(), nil)
+
+	assert.GreaterOrEqual(t, testutil.ToFloat64(m.lastSuccess), float64(before))
+}
+
+func TestPushMetrics(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Success        bool
+		ExpectedMethod string
+	}{
+		"success replaces all metrics": {
+			Success:        true,
+			ExpectedMethod: http.MethodPut,
+		},
+		"failure replaces gathered metrics": {
+			Success:        false,
+			ExpectedMethod: http.MethodPost,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			gw := &fakePushgateway{}
+
+			srv := httptest.NewServer(gw)
+			defer srv.Close()
+
+			reg := prometheus.NewRegistry()
+
+			m, err := NewReportMetrics(reg)
+			require.NoError(t, err)
+
+			m.ObserveRun(time.Now(), nil)
+
+			require.NoError(t, PushMetrics(srv.URL, "jira_wrangler", reg, tc.Success))
+
+			assert.Equal(t, tc.ExpectedMethod, gw.method)
+			assert.Equal(t, "/metrics/job/jira_wrangler", gw.path)
+		})
+	}
+}
+
+func TestPushMetrics_Error(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	require.Error(t, PushMetrics(srv.URL, "jira_wrangler", prometheus.NewRegistry(), true))
+}
+
+type fakePushgateway struct {
+	method string
+	path   string
+}
+
+func (g *fakePushgateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.method = r.Method
+	g.path = r.URL.Path
+
+	w.WriteHeader(http.StatusOK)
+}
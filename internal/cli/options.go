@@ -1,7 +1,176 @@
 package cli
 
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
 type WithOverrideTemplatePath string
 
 func (w WithOverrideTemplatePath) ConfigureTemplatedReportWriter(c *TemplatedReportWriterConfig) {
 	c.OverrideTemplatePath = string(w)
 }
+
+func (w WithOverrideTemplatePath) ConfigureEmailReportWriter(c *EmailReportWriterConfig) {
+	c.OverrideTemplatePath = string(w)
+}
+
+func (w WithOverrideTemplatePath) ConfigureServer(c *ServerConfig) {
+	c.OverrideTemplatePath = string(w)
+}
+
+type WithFormat Format
+
+func (w WithFormat) ConfigureTemplatedReportWriter(c *TemplatedReportWriterConfig) {
+	c.Format = Format(w)
+}
+
+func (w WithFormat) ConfigureStructuredReportWriter(c *StructuredReportWriterConfig) {
+	c.Format = Format(w)
+}
+
+type WithJiraURL string
+
+func (w WithJiraURL) ConfigureTemplatedReportWriter(c *TemplatedReportWriterConfig) {
+	c.JiraURL = string(w)
+}
+
+func (w WithJiraURL) ConfigureStructuredReportWriter(c *StructuredReportWriterConfig) {
+	c.JiraURL = string(w)
+}
+
+func (w WithJiraURL) ConfigureSlackReportWriter(c *SlackReportWriterConfig) {
+	c.JiraURL = string(w)
+}
+
+func (w WithJiraURL) ConfigureEmailReportWriter(c *EmailReportWriterConfig) {
+	c.JiraURL = string(w)
+}
+
+func (w WithJiraURL) ConfigureConfluenceReportWriter(c *ConfluenceReportWriterConfig) {
+	c.JiraURL = string(w)
+}
+
+func (w WithJiraURL) ConfigureJiraReportWriter(c *JiraReportWriterConfig) {
+	c.JiraURL = string(w)
+}
+
+func (w WithJiraURL) ConfigureServer(c *ServerConfig) {
+	c.JiraURL = string(w)
+}
+
+type WithSlackMaxBlocks int
+
+func (w WithSlackMaxBlocks) ConfigureSlackReportWriter(c *SlackReportWriterConfig) {
+	c.MaxBlocks = int(w)
+}
+
+type WithSlackMaxSectionText int
+
+func (w WithSlackMaxSectionText) ConfigureSlackReportWriter(c *SlackReportWriterConfig) {
+	c.MaxSectionText = int(w)
+}
+
+type WithDateLayout string
+
+func (w WithDateLayout) ConfigureTemplatedReportWriter(c *TemplatedReportWriterConfig) {
+	c.DateLayout = string(w)
+}
+
+func (w WithDateLayout) ConfigureEmailReportWriter(c *EmailReportWriterConfig) {
+	c.DateLayout = string(w)
+}
+
+func (w WithDateLayout) ConfigureConfluenceReportWriter(c *ConfluenceReportWriterConfig) {
+	c.DateLayout = string(w)
+}
+
+func (w WithDateLayout) ConfigureJiraReportWriter(c *JiraReportWriterConfig) {
+	c.DateLayout = string(w)
+}
+
+func (w WithDateLayout) ConfigureServer(c *ServerConfig) {
+	c.DateLayout = string(w)
+}
+
+type WithLocation struct {
+	*time.Location
+}
+
+func (w WithLocation) ConfigureTemplatedReportWriter(c *TemplatedReportWriterConfig) {
+	c.Location = w.Location
+}
+
+func (w WithLocation) ConfigureEmailReportWriter(c *EmailReportWriterConfig) {
+	c.Location = w.Location
+}
+
+func (w WithLocation) ConfigureConfluenceReportWriter(c *ConfluenceReportWriterConfig) {
+	c.Location = w.Location
+}
+
+func (w WithLocation) ConfigureJiraReportWriter(c *JiraReportWriterConfig) {
+	c.Location = w.Location
+}
+
+func (w WithLocation) ConfigureServer(c *ServerConfig) {
+	c.Location = w.Location
+}
+
+type WithColorScale jira.ColorScale
+
+func (w WithColorScale) ConfigureTemplatedReportWriter(c *TemplatedReportWriterConfig) {
+	c.Colors = jira.ColorScale(w)
+}
+
+func (w WithColorScale) ConfigureStructuredReportWriter(c *StructuredReportWriterConfig) {
+	c.Colors = jira.ColorScale(w)
+}
+
+func (w WithColorScale) ConfigureSlackReportWriter(c *SlackReportWriterConfig) {
+	c.Colors = jira.ColorScale(w)
+}
+
+func (w WithColorScale) ConfigureEmailReportWriter(c *EmailReportWriterConfig) {
+	c.Colors = jira.ColorScale(w)
+}
+
+func (w WithColorScale) ConfigureConfluenceReportWriter(c *ConfluenceReportWriterConfig) {
+	c.Colors = jira.ColorScale(w)
+}
+
+func (w WithColorScale) ConfigureJiraReportWriter(c *JiraReportWriterConfig) {
+	c.Colors = jira.ColorScale(w)
+}
+
+func (w WithColorScale) ConfigureServer(c *ServerConfig) {
+	c.Colors = jira.ColorScale(w)
+}
+
+type WithSMTPAuth struct {
+	Username string
+	Password string
+}
+
+func (w WithSMTPAuth) ConfigureEmailReportWriter(c *EmailReportWriterConfig) {
+	c.Username = w.Username
+	c.Password = w.Password
+}
+
+type WithTLSConfig struct {
+	*tls.Config
+}
+
+func (w WithTLSConfig) ConfigureEmailReportWriter(c *EmailReportWriterConfig) {
+	c.TLSConfig = w.Config
+}
+
+type WithMetrics struct {
+	*ReportMetrics
+}
+
+func (w WithMetrics) ConfigureServer(c *ServerConfig) {
+	c.Metrics = w.ReportMetrics
+}
@@ -2,9 +2,16 @@ package cli
 
 import (
 	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	htmltemplate "html/template"
 	"io"
+	"io/fs"
+	"net/url"
 	"os"
+	"path"
+	"strings"
 	"text/template"
 	"time"
 
@@ -15,39 +22,210 @@ type ReportWriter interface {
 	WriteReport(rpt Report) error
 }
 
+// MultiReportWriter writes the same report to every writer in order
+// and stops at the first error.
+type MultiReportWriter []ReportWriter
+
+func NewMultiReportWriter(writers ...ReportWriter) MultiReportWriter {
+	return MultiReportWriter(writers)
+}
+
+func (w MultiReportWriter) WriteReport(rpt Report) error {
+	for i, rw := range w {
+		if err := rw.WriteReport(rpt); err != nil {
+			return fmt.Errorf("writing output %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ISOYear returns the year the ISO week of the report belongs to.
+func (r Report) ISOYear() int {
+	year, _ := r.GeneratedAt.ISOWeek()
+
+	return year
+}
+
 func NewReport(title string, groups ...Group) Report {
 	now := time.Now().UTC()
 	_, week := now.ISOWeek()
 
 	return Report{
-		Groups:     groups,
-		Now:        now.Format(time.RFC822),
-		Title:      title,
-		WeekOfYear: fmt.Sprint(week),
+		Groups:      groups,
+		Now:         now.Format(time.RFC822),
+		GeneratedAt: now,
+		Title:       title,
+		WeekOfYear:  fmt.Sprint(week),
 	}
 }
 
 type Report struct {
-	Groups     []Group
-	Now        string
-	Title      string
-	WeekOfYear string
+	Groups []Group
+	Now    string
+	// GeneratedAt is the time the report was created at.
+	GeneratedAt time.Time
+	Title       string
+	WeekOfYear  string
 }
 
 type Group struct {
 	Title  string
 	Issues []jira.Issue
+	// SubGroups partitions Issues if the report is grouped.
+	SubGroups []SubGroup
+	// Completed lists issues resolved within the
+	// reporting window, if enabled for the report.
+	Completed []jira.Issue
+	// Changes since the previous snapshot, if compared to one.
+	Changes *Changes `json:"-"`
+}
+
+// NewGroup sorts issues by the given keys and splits
+// them into sub-groups according to groupBy.
+func NewGroup(
+	title string, issues []jira.Issue, sortBy []SortKey, groupBy GroupBy, colors jira.ColorScale,
+) Group {
+	SortIssues(issues, sortBy, colors)
+
+	return Group{
+		Title:     title,
+		Issues:    issues,
+		SubGroups: GroupIssues(issues, groupBy, colors),
+	}
+}
+
+type SubGroup struct {
+	Title  string
+	Issues []jira.Issue
 }
 
 //go:embed templates
 var tmplFS embed.FS
 
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatJSON     Format = "json"
+	FormatYAML     Format = "yaml"
+	// FormatConfluence renders Confluence storage format.
+	FormatConfluence Format = "confluence"
+	// FormatJiraWiki renders JIRA wiki markup.
+	FormatJiraWiki Format = "jira"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+func ParseFormat(raw string) (Format, error) {
+	switch f := Format(strings.ToLower(raw)); f {
+	case FormatText, FormatMarkdown, FormatHTML, FormatJSON, FormatYAML, FormatConfluence, FormatJiraWiki:
+		return f, nil
+	case "md":
+		return FormatMarkdown, nil
+	case "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, raw)
+	}
+}
+
+// Structured reports whether the format is serialized by a
+// StructuredReportWriter instead of rendered from templates.
+func (f Format) Structured() bool {
+	return f == FormatJSON || f == FormatYAML
+}
+
+// ContentType returns the media type of documents in the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case FormatJSON:
+		return "application/json"
+	case FormatYAML:
+		return "application/yaml"
+	case FormatConfluence:
+		return "application/xhtml+xml; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// escapesHTML reports whether the format is markup
+// that must be rendered through html/template.
+func (f Format) escapesHTML() bool {
+	return f == FormatHTML || f == FormatConfluence
+}
+
+// templateExecutor is implemented by both
+// text/template and html/template templates.
+type templateExecutor interface {
+	ExecuteTemplate(w io.Writer, name string, data any) error
+}
+
 func NewTemplatedReportWriter(out io.Writer, opts ...TemplatedReportWriterOption) (*TemplatedReportWriter, error) {
 	var cfg TemplatedReportWriterConfig
 
 	cfg.Option(opts...)
+	cfg.Default()
 
-	templates, err := template.ParseFS(tmplFS, "templates/*.tmpl")
+	templates, err := parseTemplates(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplatedReportWriter{
+		out:       out,
+		templates: templates,
+	}, nil
+}
+
+// validateRendering parses the templates of the given formats so that
+// writers publishing reports fail upfront instead of after querying JIRA.
+func validateRendering(opts []TemplatedReportWriterOption, formats ...Format) error {
+	for _, format := range formats {
+		if _, err := NewTemplatedReportWriter(nil, append(opts, WithFormat(format))...); err != nil {
+			return fmt.Errorf("loading %s templates: %w", format, err)
+		}
+	}
+
+	return nil
+}
+
+// parseTemplates parses the embedded template set of the configured
+// format followed by the override templates, if any. HTML and
+// Confluence templates are parsed with html/template so that all
+// issue data is escaped.
+func parseTemplates(cfg TemplatedReportWriterConfig) (templateExecutor, error) {
+	defaultsFS, err := fs.Sub(tmplFS, path.Join("templates", string(cfg.Format)))
+	if err != nil {
+		return nil, fmt.Errorf("loading default templates: %w", err)
+	}
+
+	funcs := templateFuncs(cfg)
+
+	if cfg.Format.escapesHTML() {
+		templates, err := htmltemplate.New("").Funcs(htmltemplate.FuncMap(funcs)).ParseFS(defaultsFS, "*.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("parsing default templates: %w", err)
+		}
+
+		if cfg.OverrideTemplatePath != "" {
+			templates, err = templates.ParseFS(os.DirFS(cfg.OverrideTemplatePath), "*.tmpl")
+			if err != nil {
+				return nil, fmt.Errorf("parsing override templates: %w", err)
+			}
+		}
+
+		return templates, nil
+	}
+
+	templates, err := template.New("").Funcs(funcs).ParseFS(defaultsFS, "*.tmpl")
 	if err != nil {
 		return nil, fmt.Errorf("parsing default templates: %w", err)
 	}
@@ -59,15 +237,12 @@ func NewTemplatedReportWriter(out io.Writer, opts ...TemplatedReportWriterOption
 		}
 	}
 
-	return &TemplatedReportWriter{
-		out:       out,
-		templates: templates,
-	}, nil
+	return templates, nil
 }
 
 type TemplatedReportWriter struct {
 	out       io.Writer
-	templates *template.Template
+	templates templateExecutor
 }
 
 func (w *TemplatedReportWriter) WriteReport(rpt Report) error {
@@ -75,7 +250,139 @@ func (w *TemplatedReportWriter) WriteReport(rpt Report) error {
 }
 
 type TemplatedReportWriterConfig struct {
+	// Format selects the embedded template set. Defaults to text.
+	Format               Format
 	OverrideTemplatePath string
+	// JiraURL is the base URL issue links are built from.
+	JiraURL string
+	// DateLayout is the layout used by the formatDate template function.
+	DateLayout string
+	// Location is the time zone dates are rendered in.
+	Location *time.Location
+	// Colors is the scale colors are labeled with.
+	Colors jira.ColorScale
+}
+
+func (c *TemplatedReportWriterConfig) Default() {
+	if c.Format == "" {
+		c.Format = FormatText
+	}
+
+	if len(c.Colors) == 0 {
+		c.Colors = jira.DefaultColorScale()
+	}
+
+	if c.DateLayout == "" {
+		c.DateLayout = DefaultDateLayout
+	}
+
+	if c.Location == nil {
+		c.Location = time.UTC
+	}
+}
+
+// templateFuncs returns the functions available to all templates:
+//
+//	formatDate <time>            renders using the configured layout
+//	formatDateAs <layout> <time> renders using the given layout
+//	issueURL <key>               links to the issue on the JIRA server
+//	badgeColor <color>           returns a CSS color for health badges
+//	statusColor <color>          returns the color of Confluence status macros
+//	colorLabel <color>           returns the label of the color in the color scale
+//	colorEmoji <color>           returns the emoji of the color in the color scale
+//	colorMarkup <color>          returns the markup of the color in the color scale
+//	toJSON <value>               renders the value as indented JSON
+//	escapeMarkdown <text>        escapes characters with a meaning in Markdown
+//	escapeWiki <text>            escapes characters with a meaning in JIRA wiki markup
+//
+// Both date functions render the zero time as an empty string.
+func templateFuncs(cfg TemplatedReportWriterConfig) template.FuncMap {
+	formatDateAs := func(layout string, t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.In(cfg.Location).Format(layout)
+	}
+
+	return template.FuncMap{
+		"formatDate": func(t time.Time) string {
+			return formatDateAs(cfg.DateLayout, t)
+		},
+		"formatDateAs": formatDateAs,
+		"issueURL": func(key string) string {
+			return issueURL(cfg.JiraURL, key)
+		},
+		"badgeColor":     badgeColor,
+		"statusColor":    statusColor,
+		"colorLabel":     cfg.Colors.Label,
+		"colorEmoji":     cfg.Colors.Emoji,
+		"colorMarkup":    cfg.Colors.Markup,
+		"escapeMarkdown": _markdownEscaper.Replace,
+		"escapeWiki":     _wikiEscaper.Replace,
+		"toJSON": func(v any) (string, error) {
+			data, err := json.MarshalIndent(v, "", "  ")
+
+			return string(data), err
+		},
+	}
+}
+
+// issueURL links to the issue with the given key on the JIRA server.
+func issueURL(baseURL, key string) string {
+	return strings.TrimSuffix(strings.TrimSpace(baseURL), "/") + "/browse/" + url.PathEscape(key)
+}
+
+var _markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`,
+	`[`, `\[`, `]`, `\]`, `<`, `&lt;`, `>`, `&gt;`,
+)
+
+// _wikiEscaper also turns line breaks into forced
+// breaks so that comments do not end list items.
+// Backslashes are written as an HTML entity as a
+// double backslash is a forced break itself.
+var _wikiEscaper = strings.NewReplacer(
+	`\`, `&#92;`, `*`, `\*`, `_`, `\_`, `{`, `\{`, `}`, `\}`,
+	`[`, `\[`, `]`, `\]`, `|`, `\|`, `^`, `\^`, `~`, `\~`,
+	`-`, `\-`, `+`, `\+`, `?`, `\?`, `#`, `\#`,
+	"\r\n", ` \\ `, "\n", ` \\ `,
+)
+
+var _badgeColors = map[string]string{
+	"":       "#6a737d",
+	"red":    "#d73a49",
+	"yellow": "#dbab09",
+	"green":  "#28a745",
+}
+
+// badgeColor returns the CSS color used for health badges.
+// Colors outside of the default scale are used verbatim
+// assuming they name a CSS color like "blue" or "grey".
+func badgeColor(c jira.Color) string {
+	if css, ok := _badgeColors[strings.ToLower(c.String())]; ok {
+		return css
+	}
+
+	return strings.ToLower(c.String())
+}
+
+var _statusColors = map[string]string{
+	"red":    "Red",
+	"yellow": "Yellow",
+	"green":  "Green",
+	"blue":   "Blue",
+	"purple": "Purple",
+}
+
+// statusColor maps colors to one of the few colors supported
+// by the Confluence status macro falling back to grey.
+func statusColor(c jira.Color) string {
+	if color, ok := _statusColors[strings.ToLower(c.String())]; ok {
+		return color
+	}
+
+	return "Grey"
 }
 
 func (c *TemplatedReportWriterConfig) Option(opts ...TemplatedReportWriterOption) {
@@ -4,17 +4,24 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thetechnick/jira-wrangler/internal/jira"
 )
 
+var testColorScale = jira.ColorScale{
+	{Value: "Blue", Label: "Not Started", Emoji: "🔵"},
+	{Value: "Red", Label: "On Fire", Emoji: "🔥"},
+}
+
 func TestReportWriter_WriteReport(t *testing.T) {
 	t.Parallel()
 
 	for name, tc := range map[string]struct {
 		Report   Report
+		Options  []TemplatedReportWriterOption
 		Expected string
 	}{
 		"happy path": {
@@ -77,6 +84,214 @@ func TestReportWriter_WriteReport(t *testing.T) {
 				"",
 			}, "\n"),
 		},
+		"target end dates": {
+			Report: Report{
+				Title:      "title",
+				WeekOfYear: "1",
+				Now:        "24 Jan 23 12:42 UTC",
+				Groups: []Group{
+					{
+						Title: "title",
+						Issues: []jira.Issue{
+							{
+								Key:           "MTSRE-1",
+								Status:        "In Progress",
+								Summary:       "Test",
+								TargetEnd:     "2023-01-20",
+								TargetEndDate: time.Date(2023, time.January, 20, 0, 0, 0, 0, time.UTC),
+								Overdue:       true,
+								History: []jira.HistoryEntry{
+									{
+										Field:  jira.FieldColor,
+										At:     time.Date(2023, time.January, 17, 8, 0, 0, 0, time.UTC),
+										Author: "Jane Doe",
+										From:   "Green",
+										To:     "Red",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Options: []TemplatedReportWriterOption{
+				WithDateLayout("02 Jan 2006"),
+			},
+			Expected: strings.Join([]string{
+				"title",
+				"Week 1 - 24 Jan 23 12:42 UTC",
+				"",
+				"title",
+				"- [MTSRE-1] Test",
+				"  Status:\tIn Progress",
+				"  TargetEnd:\t20 Jan 2023 (overdue)",
+				"  Tue 17 Jan:\tcolor Green -> Red (Jane Doe)",
+				"",
+			}, "\n"),
+		},
+		"changes": {
+			Report: Report{
+				Title:      "title",
+				WeekOfYear: "1",
+				Now:        "24 Jan 23 12:42 UTC",
+				Groups: []Group{
+					{
+						Title: "title",
+						Issues: []jira.Issue{
+							{
+								Color:   jira.ColorRed,
+								Key:     "MTSRE-1",
+								Status:  "In Progress",
+								Summary: "Test",
+							},
+						},
+						Changes: &Changes{
+							Since:   "17 Jan 23 12:42 UTC",
+							Removed: []jira.Issue{{Key: "MTSRE-2", Summary: "Done"}},
+							ColorChanges: []IssueChange{
+								{
+									Issue:    jira.Issue{Key: "MTSRE-1", Color: jira.ColorRed},
+									Previous: jira.Issue{Key: "MTSRE-1", Color: jira.ColorGreen},
+								},
+							},
+						},
+					},
+				},
+			},
+			Expected: strings.Join([]string{
+				"title",
+				"Week 1 - 24 Jan 23 12:42 UTC",
+				"",
+				"title",
+				"Changes since 17 Jan 23 12:42 UTC:",
+				"  - [MTSRE-2] Done (closed or removed)",
+				"  * [MTSRE-1] Color Green -> Red",
+				"- [MTSRE-1] Test",
+				"  Status:\tIn Progress",
+				"  Color:\tRed",
+				"",
+			}, "\n"),
+		},
+		"completed": {
+			Report: Report{
+				Title:      "title",
+				WeekOfYear: "1",
+				Now:        "24 Jan 23 12:42 UTC",
+				Groups: []Group{
+					{
+						Title: "title",
+						Issues: []jira.Issue{
+							{
+								Key:     "MTSRE-1",
+								Status:  "In Progress",
+								Summary: "Test",
+							},
+						},
+						Completed: []jira.Issue{
+							{
+								Key:            "MTSRE-2",
+								Status:         "Closed",
+								Summary:        "Done",
+								Resolution:     "Done",
+								ResolutionDate: time.Date(2023, time.January, 23, 16, 0, 0, 0, time.UTC),
+							},
+						},
+					},
+				},
+			},
+			Expected: strings.Join([]string{
+				"title",
+				"Week 1 - 24 Jan 23 12:42 UTC",
+				"",
+				"title",
+				"- [MTSRE-1] Test",
+				"  Status:\tIn Progress",
+				"Completed:",
+				"- [MTSRE-2] Done",
+				"  Resolution:\tDone",
+				"  Resolved:\t2023-01-23",
+				"",
+			}, "\n"),
+		},
+		"sub-groups": {
+			Report: Report{
+				Title:      "title",
+				WeekOfYear: "1",
+				Now:        "24 Jan 23 12:42 UTC",
+				Groups: []Group{
+					NewGroup("title", []jira.Issue{
+						{
+							Color:   jira.ColorGreen,
+							Key:     "MTSRE-1",
+							Status:  "In Progress",
+							Summary: "Test",
+						},
+						{
+							Color:   jira.ColorRed,
+							Key:     "MTSRE-2",
+							Status:  "New",
+							Summary: "Test 2",
+						},
+					}, DefaultSortBy, GroupByColor, jira.DefaultColorScale()),
+				},
+			},
+			Expected: strings.Join([]string{
+				"title",
+				"Week 1 - 24 Jan 23 12:42 UTC",
+				"",
+				"title",
+				"## Red",
+				"- [MTSRE-2] Test 2",
+				"  Status:\tNew",
+				"  Color:\tRed",
+				"## Green",
+				"- [MTSRE-1] Test",
+				"  Status:\tIn Progress",
+				"  Color:\tGreen",
+				"",
+			}, "\n"),
+		},
+		"custom color scale": {
+			Report: Report{
+				Title:      "title",
+				WeekOfYear: "1",
+				Now:        "24 Jan 23 12:42 UTC",
+				Groups: []Group{
+					NewGroup("title", []jira.Issue{
+						{
+							Color:   jira.ColorRed,
+							Key:     "MTSRE-1",
+							Status:  "In Progress",
+							Summary: "Test",
+						},
+						{
+							Color:   "Blue",
+							Key:     "MTSRE-2",
+							Status:  "New",
+							Summary: "Test 2",
+						},
+					}, DefaultSortBy, GroupByColor, testColorScale),
+				},
+			},
+			Options: []TemplatedReportWriterOption{
+				WithColorScale(testColorScale),
+			},
+			Expected: strings.Join([]string{
+				"title",
+				"Week 1 - 24 Jan 23 12:42 UTC",
+				"",
+				"title",
+				"## Not Started",
+				"- [MTSRE-2] Test 2",
+				"  Status:\tNew",
+				"  Color:\tNot Started",
+				"## On Fire",
+				"- [MTSRE-1] Test",
+				"  Status:\tIn Progress",
+				"  Color:\tOn Fire",
+				"",
+			}, "\n"),
+		},
 	} {
 		tc := tc
 
@@ -85,7 +300,7 @@ func TestReportWriter_WriteReport(t *testing.T) {
 
 			var buf bytes.Buffer
 
-			rw, err := NewTemplatedReportWriter(&buf)
+			rw, err := NewTemplatedReportWriter(&buf, tc.Options...)
 			require.NoError(t, err)
 
 			require.NoError(t, rw.WriteReport(tc.Report))
@@ -94,3 +309,130 @@ func TestReportWriter_WriteReport(t *testing.T) {
 		})
 	}
 }
+
+func TestReportWriter_WriteReport_Formats(t *testing.T) {
+	t.Parallel()
+
+	rpt := Report{
+		Title:      "title",
+		WeekOfYear: "1",
+		Now:        "24 Jan 23 12:42 UTC",
+		Groups: []Group{
+			{
+				Title: "title",
+				Issues: []jira.Issue{
+					{
+						Color:         jira.ColorRed,
+						Key:           "MTSRE-1",
+						Status:        "In Progress",
+						Summary:       "Fix <script>alert(1)</script> *now*",
+						StatusComment: "blocked",
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Format   Format
+		Expected string
+	}{
+		"markdown": {
+			Format: FormatMarkdown,
+			Expected: strings.Join([]string{
+				"# title",
+				"",
+				"_Week 1 - 24 Jan 23 12:42 UTC_",
+				"",
+				"## title",
+				"",
+				"- 🔴 **Red** [MTSRE-1](https://jira.example.com/browse/MTSRE-1) Fix &lt;script&gt;alert(1)&lt;/script&gt; \\*now\\*",
+				"  - Status: In Progress",
+				"  - Comment: blocked",
+				"",
+			}, "\n"),
+		},
+		"html": {
+			Format: FormatHTML,
+			Expected: strings.Join([]string{
+				"<!DOCTYPE html>",
+				"<html>",
+				"<head>",
+				`<meta charset="utf-8">`,
+				"<title>title</title>",
+				"</head>",
+				`<body style="font-family: sans-serif">`,
+				"<h1>title</h1>",
+				"<p><em>Week 1 - 24 Jan 23 12:42 UTC</em></p>",
+				"<h2>title</h2>",
+				"<ul>",
+				"<li>",
+				`<span style="background-color: #d73a49; color: #ffffff; border-radius: 3px; padding: 1px 6px; font-size: 0.85em">Red</span> ` +
+					`<a href="https://jira.example.com/browse/MTSRE-1">MTSRE-1</a> Fix &lt;script&gt;alert(1)&lt;/script&gt; *now*`,
+				"<ul>",
+				"<li>Status: In Progress</li>",
+				"<li>Comment: blocked</li>",
+				"</ul>",
+				"</li>",
+				"</ul>",
+				"</body>",
+				"</html>",
+				"",
+			}, "\n"),
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			rw, err := NewTemplatedReportWriter(
+				&buf,
+				WithFormat(tc.Format),
+				WithJiraURL("https://jira.example.com/"),
+			)
+			require.NoError(t, err)
+
+			require.NoError(t, rw.WriteReport(rpt))
+
+			assert.Equal(t, tc.Expected, buf.String())
+		})
+	}
+}
+
+func TestMultiReportWriter_WriteReport(t *testing.T) {
+	t.Parallel()
+
+	rpt := Report{Title: "title", WeekOfYear: "4"}
+
+	var text, structured bytes.Buffer
+
+	textWriter, err := NewTemplatedReportWriter(&text)
+	require.NoError(t, err)
+
+	rw := NewMultiReportWriter(
+		textWriter,
+		NewStructuredReportWriter(&structured),
+	)
+
+	require.NoError(t, rw.WriteReport(rpt))
+
+	assert.Contains(t, text.String(), "Week 4")
+	assert.Contains(t, structured.String(), `"week": 4`)
+}
+
+func TestMultiReportWriter_WriteReport_Error(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	rw := NewMultiReportWriter(
+		NewStructuredReportWriter(&bytes.Buffer{}, WithFormat(FormatHTML)),
+		NewStructuredReportWriter(&out),
+	)
+
+	require.ErrorIs(t, rw.WriteReport(Report{}), ErrUnknownFormat)
+	assert.Empty(t, out.String())
+}
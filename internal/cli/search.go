@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+	"golang.org/x/sync/singleflight"
+)
+
+// IssueSearcher is implemented by jira.Client.
+type IssueSearcher interface {
+	SearchIssues(ctx context.Context, jql string) ([]jira.Issue, error)
+}
+
+// BuildGroup queries the issues of a single report section
+// and sorts them using the given color scale.
+func BuildGroup(
+	ctx context.Context, searcher IssueSearcher, cfg ReportConfig, colors jira.ColorScale,
+) (Group, error) {
+	issues, err := searcher.SearchIssues(ctx, cfg.Query())
+	if err != nil {
+		return Group{}, fmt.Errorf("generating report: %w", err)
+	}
+
+	group := NewGroup(cfg.Title, issues, cfg.SortKeys(), cfg.GroupBy, colors)
+
+	if jql := cfg.CompletedQuery(); jql != "" {
+		completed, err := searcher.SearchIssues(ctx, jql)
+		if err != nil {
+			return Group{}, fmt.Errorf("generating completed section: %w", err)
+		}
+
+		group.Completed = completed
+	}
+
+	return group, nil
+}
+
+// cachedSearchTimeout bounds searches shared by CachedSearcher
+// as they are independent of the requests waiting for them.
+const cachedSearchTimeout = 2 * time.Minute
+
+func NewCachedSearcher(next IssueSearcher, ttl time.Duration) *CachedSearcher {
+	return &CachedSearcher{
+		next:    next,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+// CachedSearcher keeps search results for a fixed time and
+// merges concurrent searches for the same query.
+type CachedSearcher struct {
+	next IssueSearcher
+	ttl  time.Duration
+	now  func() time.Time
+
+	group   singleflight.Group
+	lock    sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	issues    []jira.Issue
+	expiresAt time.Time
+}
+
+func (s *CachedSearcher) SearchIssues(ctx context.Context, jql string) ([]jira.Issue, error) {
+	if issues, ok := s.lookup(jql); ok {
+		return issues, nil
+	}
+
+	ch := s.group.DoChan(jql, func() (interface{}, error) {
+		// Searches are shared by all callers, so they must
+		// not be cancelled when the first caller goes away.
+		ctx, cancel := context.WithTimeout(context.Background(), cachedSearchTimeout)
+		defer cancel()
+
+		issues, err := s.next.SearchIssues(ctx, jql)
+		if err != nil {
+			return nil, err
+		}
+
+		s.lock.Lock()
+		s.entries[jql] = cacheEntry{issues: issues, expiresAt: s.now().Add(s.ttl)}
+		s.lock.Unlock()
+
+		return issues, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		return copyIssues(res.Val.([]jira.Issue)), nil
+	}
+}
+
+func (s *CachedSearcher) lookup(jql string) ([]jira.Issue, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.entries[jql]
+	if !ok || !s.now().Before(e.expiresAt) {
+		return nil, false
+	}
+
+	return copyIssues(e.issues), true
+}
+
+// copyIssues returns a copy of issues which is safe to sort.
+func copyIssues(issues []jira.Issue) []jira.Issue {
+	return append([]jira.Issue(nil), issues...)
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestCachedSearcher_SearchIssues(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	s := NewCachedSearcher(fakeSearcher(func(jql string) ([]jira.Issue, error) {
+		calls.Add(1)
+
+		return []jira.Issue{{Key: "SDE-2"}, {Key: "SDE-1"}}, nil
+	}), time.Minute)
+
+	now := time.Date(2023, time.January, 24, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	issues, err := s.SearchIssues(context.Background(), "project = SDE")
+	require.NoError(t, err)
+
+	// Callers may sort results without affecting the cache.
+	issues[0], issues[1] = issues[1], issues[0]
+
+	issues, err = s.SearchIssues(context.Background(), "project = SDE")
+	require.NoError(t, err)
+	assert.Equal(t, "SDE-2", issues[0].Key)
+	assert.Equal(t, int32(1), calls.Load())
+
+	_, err = s.SearchIssues(context.Background(), "project = OTHER")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+
+	now = now.Add(time.Minute)
+
+	_, err = s.SearchIssues(context.Background(), "project = SDE")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCachedSearcher_SearchIssues_CallerCancelled(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	s := NewCachedSearcher(ctxSearcher(func(ctx context.Context) ([]jira.Issue, error) {
+		close(started)
+		<-release
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return []jira.Issue{{Key: "SDE-1"}}, nil
+	}), time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := s.SearchIssues(ctx, "project = SDE")
+		first <- err
+	}()
+
+	<-started
+
+	second := make(chan []jira.Issue, 1)
+	go func() {
+		issues, _ := s.SearchIssues(context.Background(), "project = SDE")
+		second <- issues
+	}()
+
+	// The first caller going away must not fail the shared search.
+	cancel()
+	require.ErrorIs(t, <-first, context.Canceled)
+
+	close(release)
+
+	issues := <-second
+	require.Len(t, issues, 1)
+	assert.Equal(t, "SDE-1", issues[0].Key)
+}
+
+type ctxSearcher func(ctx context.Context) ([]jira.Issue, error)
+
+func (f ctxSearcher) SearchIssues(ctx context.Context, _ string) ([]jira.Issue, error) {
+	return f(ctx)
+}
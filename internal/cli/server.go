@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func NewServer(cfg *Config, searcher IssueSearcher, opts ...ServerOption) *Server {
+	var scfg ServerConfig
+
+	scfg.Option(opts...)
+	scfg.Default()
+
+	reports := make(map[string]ReportConfig, len(cfg.Reports))
+	for _, rpt := range cfg.Reports {
+		reports[rpt.ReportName()] = rpt
+	}
+
+	return &Server{
+		cfg:      scfg,
+		config:   cfg,
+		searcher: searcher,
+		reports:  reports,
+	}
+}
+
+// Server renders configured reports on demand:
+//
+//	GET /reports                     lists the names of all reports
+//	GET /reports/{name}?format=html  renders a single report
+//	GET /healthz                     reports liveness
+//	GET /readyz                      reports readiness, see MarkReady
+type Server struct {
+	cfg      ServerConfig
+	config   *Config
+	searcher IssueSearcher
+	reports  map[string]ReportConfig
+	ready    atomic.Bool
+}
+
+// MarkReady makes /readyz succeed and reports to be served,
+// e.g. once JIRA fields are resolved.
+func (s *Server) MarkReady() {
+	s.ready.Store(true)
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if !s.ready.Load() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/reports", s.handleList)
+	mux.HandleFunc("/reports/", s.handleReport)
+
+	return mux
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	names := make([]string, 0, len(s.config.Reports))
+	for _, rpt := range s.config.Reports {
+		names = append(names, rpt.ReportName())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(names)
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	// Issues searched before fields are resolved lack custom
+	// fields and would be cached for the whole cache TTL.
+	if !s.ready.Load() {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	reportCfg, ok := s.reports[strings.TrimPrefix(r.URL.Path, "/reports/")]
+	if !ok {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	format := FormatHTML
+	if raw := r.URL.Query().Get("format"); raw != "" {
+		var err error
+		if format, err = ParseFormat(raw); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	start := time.Now()
+
+	group, err := BuildGroup(r.Context(), s.searcher, reportCfg, s.cfg.Colors)
+	s.observe(reportCfg, group, start, err)
+
+	if err != nil {
+		log.Printf("rendering report %q: %v", reportCfg.ReportName(), err)
+		http.Error(w, "querying JIRA failed", http.StatusBadGateway)
+
+		return
+	}
+
+	var buf bytes.Buffer
+
+	rw, err := s.reportWriter(&buf, format)
+	if err != nil {
+		log.Printf("rendering report %q: %v", reportCfg.ReportName(), err)
+		http.Error(w, "rendering report failed", http.StatusInternalServerError)
+
+		return
+	}
+
+	if err := rw.WriteReport(NewReport(s.config.Title, group)); err != nil {
+		log.Printf("rendering report %q: %v", reportCfg.ReportName(), err)
+		http.Error(w, "rendering report failed", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	_, _ = buf.WriteTo(w)
+}
+
+func (s *Server) observe(reportCfg ReportConfig, group Group, start time.Time, err error) {
+	if s.cfg.Metrics == nil {
+		return
+	}
+
+	if err == nil {
+		s.cfg.Metrics.ObserveGroup(reportCfg.ReportName(), group)
+	}
+
+	s.cfg.Metrics.ObserveRun(start, err)
+}
+
+func (s *Server) reportWriter(buf *bytes.Buffer, format Format) (ReportWriter, error) {
+	if format.Structured() {
+		return NewStructuredReportWriter(
+			buf, WithFormat(format), WithJiraURL(s.cfg.JiraURL), WithColorScale(s.cfg.Colors),
+		), nil
+	}
+
+	return NewTemplatedReportWriter(
+		buf,
+		WithFormat(format),
+		WithJiraURL(s.cfg.JiraURL),
+		WithOverrideTemplatePath(s.cfg.OverrideTemplatePath),
+		WithDateLayout(s.cfg.DateLayout),
+		WithLocation{Location: s.cfg.Location},
+		WithColorScale(s.cfg.Colors),
+	)
+}
+
+type ServerConfig struct {
+	// JiraURL, OverrideTemplatePath, DateLayout and Location
+	// apply to every report served, whatever format is requested.
+	JiraURL              string
+	OverrideTemplatePath string
+	DateLayout           string
+	Location             *time.Location
+	// Colors is the scale issues are sorted and labeled with.
+	Colors jira.ColorScale
+	// Metrics records every rendered report as a run, if set.
+	Metrics *ReportMetrics
+}
+
+func (c *ServerConfig) Option(opts ...ServerOption) {
+	for _, opt := range opts {
+		opt.ConfigureServer(c)
+	}
+}
+
+func (c *ServerConfig) Default() {
+	if c.DateLayout == "" {
+		c.DateLayout = DefaultDateLayout
+	}
+
+	if c.Location == nil {
+		c.Location = time.UTC
+	}
+
+	if len(c.Colors) == 0 {
+		c.Colors = jira.DefaultColorScale()
+	}
+}
+
+type ServerOption interface {
+	ConfigureServer(*ServerConfig)
+}
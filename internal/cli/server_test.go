@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Title: "Status",
+		Reports: []ReportConfig{
+			{Title: "NASA", Label: "nasa"},
+			{Title: "Broken", Label: "broken"},
+		},
+	}
+
+	searcher := fakeSearcher(func(jql string) ([]jira.Issue, error) {
+		if jql == (ReportConfig{Label: "broken"}).Query() {
+			return nil, errors.New("boom")
+		}
+
+		return []jira.Issue{{Key: "SDE-1", Summary: "a <b> c", Color: jira.ColorRed}}, nil
+	})
+
+	for name, tc := range map[string]struct {
+		Path                string
+		ExpectedStatus      int
+		ExpectedContentType string
+		ExpectedBody        string
+	}{
+		"healthz": {
+			Path:           "/healthz",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "ok",
+		},
+		"list": {
+			Path:                "/reports",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: "application/json",
+			ExpectedBody:        `["nasa","broken"]`,
+		},
+		"html by default": {
+			Path:                "/reports/nasa",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: "text/html; charset=utf-8",
+			ExpectedBody:        `<a href="https://jira.example.com/browse/SDE-1">SDE-1</a> a &lt;b&gt; c`,
+		},
+		"markdown": {
+			Path:                "/reports/nasa?format=md",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: "text/markdown; charset=utf-8",
+			ExpectedBody:        "[SDE-1](https://jira.example.com/browse/SDE-1) a &lt;b&gt; c",
+		},
+		"json": {
+			Path:                "/reports/nasa?format=json",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedContentType: "application/json",
+			ExpectedBody:        `"key": "SDE-1"`,
+		},
+		"unknown format": {
+			Path:           "/reports/nasa?format=pdf",
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   "unknown format",
+		},
+		"unknown report": {
+			Path:           "/reports/unknown",
+			ExpectedStatus: http.StatusNotFound,
+		},
+		"jira error": {
+			Path:           "/reports/broken",
+			ExpectedStatus: http.StatusBadGateway,
+			ExpectedBody:   "querying JIRA failed",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := NewServer(cfg, searcher, WithJiraURL("https://jira.example.com"))
+			srv.MarkReady()
+
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.Path, nil))
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedStatus, res.StatusCode)
+			assert.Contains(t, string(body), tc.ExpectedBody)
+
+			if tc.ExpectedContentType != "" {
+				assert.Equal(t, tc.ExpectedContentType, res.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestServer_Readyz(t *testing.T) {
+	t.Parallel()
+
+	var searches int
+
+	cfg := &Config{Reports: []ReportConfig{{Title: "NASA", Label: "nasa"}}}
+	srv := NewServer(cfg, fakeSearcher(func(string) ([]jira.Issue, error) {
+		searches++
+
+		return nil, nil
+	}))
+
+	for path, expected := range map[string]int{
+		"/readyz":       http.StatusServiceUnavailable,
+		"/reports/nasa": http.StatusServiceUnavailable,
+	} {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, expected, rec.Code, path)
+	}
+
+	assert.Zero(t, searches, "JIRA is not searched before the server is ready")
+
+	srv.MarkReady()
+
+	for _, path := range []string{"/readyz", "/reports/nasa"} {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+	}
+}
+
+type fakeSearcher func(jql string) ([]jira.Issue, error)
+
+func (f fakeSearcher) SearchIssues(_ context.Context, jql string) ([]jira.Issue, error) {
+	return f(jql)
+}
+
+func TestServer_Metrics(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+
+	metrics, err := NewReportMetrics(reg)
+	require.NoError(t, err)
+
+	cfg := &Config{Reports: []ReportConfig{{Title: "NASA", Label: "nasa"}}}
+	srv := NewServer(cfg, fakeSearcher(func(string) ([]jira.Issue, error) {
+		return []jira.Issue{{Key: "SDE-1", Color: jira.ColorRed, Status: "To Do"}}, nil
+	}), WithMetrics{ReportMetrics: metrics})
+	srv.MarkReady()
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/reports/nasa", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.issues.WithLabelValues("nasa", "Red", "To Do")))
+	assert.Equal(t, 1, testutil.CollectAndCount(reg, "jira_wrangler_last_success_timestamp_seconds"))
+}
@@ -0,0 +1,345 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+// Limits of incoming webhook messages, see
+// https://api.slack.com/reference/block-kit/blocks.
+const (
+	DefaultSlackMaxBlocks      = 50
+	DefaultSlackMaxSectionText = 3000
+	DefaultSlackMaxMessageText = 40000
+	slackMaxHeaderText         = 150
+)
+
+var ErrSlackRequestFailed = errors.New("slack request failed")
+
+func NewSlackReportWriter(client *http.Client, webhookURL string, opts ...SlackReportWriterOption) *SlackReportWriter {
+	var cfg SlackReportWriterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &SlackReportWriter{
+		cfg:        cfg,
+		client:     client,
+		webhookURL: webhookURL,
+	}
+}
+
+// SlackReportWriter posts reports to a Slack incoming webhook
+// using one header and a number of sections per group. Reports
+// exceeding the limits of a single message are split across
+// several messages.
+type SlackReportWriter struct {
+	cfg        SlackReportWriterConfig
+	client     *http.Client
+	webhookURL string
+}
+
+func (w *SlackReportWriter) WriteReport(rpt Report) error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.Timeout)
+	defer cancel()
+
+	for i, msg := range w.messages(rpt) {
+		if err := w.post(ctx, msg); err != nil {
+			return fmt.Errorf("posting message %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (w *SlackReportWriter) post(ctx context.Context, msg slackMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshalling message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.webhookURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s: %s", ErrSlackRequestFailed, res.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// messages converts rpt into Block Kit messages, starting
+// a new message whenever one would exceed the configured limits.
+func (w *SlackReportWriter) messages(rpt Report) []slackMessage {
+	var (
+		msgs   []slackMessage
+		cur    slackMessage
+		length int
+	)
+
+	for _, b := range w.blocks(rpt) {
+		if len(cur.Blocks) > 0 &&
+			(len(cur.Blocks) == w.cfg.MaxBlocks || length+b.length() > w.cfg.MaxMessageText) {
+			msgs = append(msgs, cur)
+			cur, length = slackMessage{}, 0
+		}
+
+		cur.Blocks = append(cur.Blocks, b)
+		length += b.length()
+	}
+
+	if len(cur.Blocks) > 0 {
+		msgs = append(msgs, cur)
+	}
+
+	for i := range msgs {
+		// Fallback used in notifications.
+		msgs[i].Text = fmt.Sprintf("%s - Week %s", rpt.Title, rpt.WeekOfYear)
+		if len(msgs) > 1 {
+			msgs[i].Text += fmt.Sprintf(" (%d/%d)", i+1, len(msgs))
+		}
+	}
+
+	return msgs
+}
+
+func (w *SlackReportWriter) blocks(rpt Report) []slackBlock {
+	blocks := []slackBlock{
+		slackHeader(rpt.Title),
+		{
+			Type: "context",
+			Elements: []slackText{
+				{Type: "mrkdwn", Text: escapeSlack(fmt.Sprintf("Week %s - %s", rpt.WeekOfYear, rpt.Now))},
+			},
+		},
+	}
+
+	for _, g := range rpt.Groups {
+		blocks = append(blocks, slackHeader(g.Title))
+		blocks = append(blocks, w.sections(w.groupLines(g))...)
+	}
+
+	return blocks
+}
+
+func (w *SlackReportWriter) groupLines(g Group) []string {
+	var lines []string
+
+	if len(g.SubGroups) > 0 {
+		for _, sg := range g.SubGroups {
+			title := sg.Title
+			if title == "" {
+				title = "None"
+			}
+
+			lines = append(lines, "*"+escapeSlack(title)+"*")
+
+			for _, i := range sg.Issues {
+				lines = append(lines, w.issueLine(i))
+			}
+		}
+	} else {
+		for _, i := range g.Issues {
+			lines = append(lines, w.issueLine(i))
+		}
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "_No issues_")
+	}
+
+	if len(g.Completed) > 0 {
+		lines = append(lines, "*Completed*")
+
+		for _, i := range g.Completed {
+			lines = append(lines, ":white_check_mark: "+w.issueLink(i)+" "+escapeSlack(i.Summary))
+		}
+	}
+
+	return lines
+}
+
+func (w *SlackReportWriter) issueLine(i jira.Issue) string {
+	emoji := w.cfg.Colors.Emoji(i.Color)
+	if emoji == "" {
+		emoji = ":white_circle:"
+	}
+
+	line := fmt.Sprintf("%s %s %s (%s)", emoji, w.issueLink(i), escapeSlack(i.Summary), escapeSlack(i.Status))
+
+	if i.TargetEnd != "" {
+		line += " - target end " + escapeSlack(i.TargetEnd)
+		if i.Overdue {
+			line += " (overdue)"
+		}
+	}
+
+	if i.StatusComment != "" {
+		line += "\n>" + strings.ReplaceAll(escapeSlack(i.StatusComment), "\n", "\n>")
+	}
+
+	return line
+}
+
+func (w *SlackReportWriter) issueLink(i jira.Issue) string {
+	if w.cfg.JiraURL == "" {
+		return escapeSlack(i.Key)
+	}
+
+	return "<" + issueURL(w.cfg.JiraURL, i.Key) + "|" + escapeSlack(i.Key) + ">"
+}
+
+// sections packs lines into as few section blocks as possible
+// truncating lines which exceed the section limit on their own.
+func (w *SlackReportWriter) sections(lines []string) []slackBlock {
+	var (
+		blocks []slackBlock
+		text   string
+	)
+
+	for _, line := range lines {
+		line = truncate(line, w.cfg.MaxSectionText)
+
+		if text != "" && len([]rune(text))+1+len([]rune(line)) > w.cfg.MaxSectionText {
+			blocks = append(blocks, slackSection(text))
+			text = ""
+		}
+
+		if text != "" {
+			text += "\n"
+		}
+
+		text += line
+	}
+
+	if text != "" {
+		blocks = append(blocks, slackSection(text))
+	}
+
+	return blocks
+}
+
+type SlackReportWriterConfig struct {
+	// Timeout limits posting a single report. Defaults to 1m.
+	Timeout time.Duration
+	// JiraURL is the base URL issue links are built from.
+	JiraURL string
+	// MaxBlocks is the maximum number of blocks per message.
+	MaxBlocks int
+	// MaxSectionText is the maximum length of a single section.
+	MaxSectionText int
+	// MaxMessageText is the maximum length of all text in a message.
+	MaxMessageText int
+	// Colors is the scale color emojis are taken from.
+	Colors jira.ColorScale
+}
+
+func (c *SlackReportWriterConfig) Option(opts ...SlackReportWriterOption) {
+	for _, opt := range opts {
+		opt.ConfigureSlackReportWriter(c)
+	}
+}
+
+func (c *SlackReportWriterConfig) Default() {
+	if c.Timeout <= 0 {
+		c.Timeout = time.Minute
+	}
+
+	if c.MaxBlocks <= 0 {
+		c.MaxBlocks = DefaultSlackMaxBlocks
+	}
+
+	if c.MaxSectionText <= 0 {
+		c.MaxSectionText = DefaultSlackMaxSectionText
+	}
+
+	if c.MaxMessageText <= 0 {
+		c.MaxMessageText = DefaultSlackMaxMessageText
+	}
+
+	if len(c.Colors) == 0 {
+		c.Colors = jira.DefaultColorScale()
+	}
+}
+
+type SlackReportWriterOption interface {
+	ConfigureSlackReportWriter(*SlackReportWriterConfig)
+}
+
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+func (b slackBlock) length() int {
+	n := 0
+	if b.Text != nil {
+		n += len([]rune(b.Text.Text))
+	}
+
+	for _, e := range b.Elements {
+		n += len([]rune(e.Text))
+	}
+
+	return n
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func slackHeader(text string) slackBlock {
+	return slackBlock{
+		Type: "header",
+		Text: &slackText{Type: "plain_text", Text: truncate(text, slackMaxHeaderText)},
+	}
+}
+
+func slackSection(text string) slackBlock {
+	return slackBlock{
+		Type: "section",
+		Text: &slackText{Type: "mrkdwn", Text: text},
+	}
+}
+
+// escapeSlack escapes the control characters of Slack's mrkdwn.
+var escapeSlack = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
+
+// truncate shortens s to at most n runes marking truncation with an ellipsis.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestSlackReportWriter_WriteReport(t *testing.T) {
+	t.Parallel()
+
+	rpt := Report{
+		Title:      "title",
+		WeekOfYear: "4",
+		Now:        "24 Jan 23 12:42 UTC",
+		Groups: []Group{
+			{
+				Title: "group",
+				Issues: []jira.Issue{
+					{
+						Key:           "SDE-1",
+						Color:         jira.ColorRed,
+						Status:        "In Progress",
+						Summary:       "a <b> & c",
+						StatusComment: "blocked\non networking",
+					},
+					{
+						Key:     "SDE-2",
+						Status:  "New",
+						Summary: "summary",
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Options  []SlackReportWriterOption
+		Expected []slackMessage
+	}{
+		"single message": {
+			Expected: []slackMessage{
+				{
+					Text: "title - Week 4",
+					Blocks: []slackBlock{
+						slackHeader("title"),
+						{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: "Week 4 - 24 Jan 23 12:42 UTC"}}},
+						slackHeader("group"),
+						slackSection(strings.Join([]string{
+							"🔴 <https://jira.example.com/browse/SDE-1|SDE-1> a &lt;b&gt; &amp; c (In Progress)",
+							">blocked",
+							">on networking",
+							":white_circle: <https://jira.example.com/browse/SDE-2|SDE-2> summary (New)",
+						}, "\n")),
+					},
+				},
+			},
+		},
+		"split": {
+			Options: []SlackReportWriterOption{
+				WithSlackMaxBlocks(3),
+				WithSlackMaxSectionText(80),
+			},
+			Expected: []slackMessage{
+				{
+					Text: "title - Week 4 (1/2)",
+					Blocks: []slackBlock{
+						slackHeader("title"),
+						{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: "Week 4 - 24 Jan 23 12:42 UTC"}}},
+						slackHeader("group"),
+					},
+				},
+				{
+					Text: "title - Week 4 (2/2)",
+					Blocks: []slackBlock{
+						slackSection("🔴 <https://jira.example.com/browse/SDE-1|SDE-1> a &lt;b&gt; &amp; c (In Progres…"),
+						slackSection(":white_circle: <https://jira.example.com/browse/SDE-2|SDE-2> summary (New)"),
+					},
+				},
+			},
+		},
+		"custom color scale": {
+			Options: []SlackReportWriterOption{
+				WithColorScale{{Value: "Red", Emoji: ":fire:"}, {Value: "", Emoji: ":grey_question:"}},
+			},
+			Expected: []slackMessage{
+				{
+					Text: "title - Week 4",
+					Blocks: []slackBlock{
+						slackHeader("title"),
+						{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: "Week 4 - 24 Jan 23 12:42 UTC"}}},
+						slackHeader("group"),
+						slackSection(strings.Join([]string{
+							":fire: <https://jira.example.com/browse/SDE-1|SDE-1> a &lt;b&gt; &amp; c (In Progress)",
+							">blocked",
+							">on networking",
+							":grey_question: <https://jira.example.com/browse/SDE-2|SDE-2> summary (New)",
+						}, "\n")),
+					},
+				},
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lock     sync.Mutex
+				received []slackMessage
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var msg slackMessage
+				if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				lock.Lock()
+				received = append(received, msg)
+				lock.Unlock()
+
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer srv.Close()
+
+			rw := NewSlackReportWriter(
+				srv.Client(), srv.URL,
+				append(tc.Options, WithJiraURL("https://jira.example.com"))...,
+			)
+
+			require.NoError(t, rw.WriteReport(rpt))
+
+			assert.Equal(t, tc.Expected, received)
+		})
+	}
+}
+
+func TestSlackReportWriter_WriteReport_Error(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("invalid_blocks"))
+	}))
+	defer srv.Close()
+
+	rw := NewSlackReportWriter(srv.Client(), srv.URL)
+
+	err := rw.WriteReport(Report{Title: "title"})
+	require.ErrorIs(t, err, ErrSlackRequestFailed)
+	assert.Contains(t, err.Error(), "invalid_blocks")
+}
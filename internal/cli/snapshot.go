@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	snapshotVersion    = 1
+	snapshotTimeLayout = "20060102T150405Z"
+	snapshotPrefix     = "snapshot-"
+	snapshotExt        = ".json"
+)
+
+var (
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+	ErrUnsupportedReportSchema    = errors.New("unsupported report schema")
+)
+
+// Snapshot is the persisted state of a single run.
+type Snapshot struct {
+	Version int       `json:"version"`
+	TakenAt time.Time `json:"takenAt"`
+	Report  Report    `json:"report"`
+}
+
+func NewSnapshotStore(dir string) *SnapshotStore {
+	return &SnapshotStore{dir: dir}
+}
+
+// SnapshotStore keeps one JSON file per snapshot in a directory
+// named after the time they were taken so that they sort
+// chronologically.
+type SnapshotStore struct {
+	dir string
+}
+
+// Save persists rpt as a snapshot taken at the given time.
+func (s *SnapshotStore) Save(rpt Report, takenAt time.Time) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("creating snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(Snapshot{
+		Version: snapshotVersion,
+		TakenAt: takenAt.UTC(),
+		Report:  rpt,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling snapshot: %w", err)
+	}
+
+	name := snapshotPrefix + takenAt.UTC().Format(snapshotTimeLayout) + snapshotExt
+
+	// Write to a temporary file first so that an interrupted
+	// run never leaves a truncated latest snapshot behind.
+	tmp, err := os.CreateTemp(s.dir, "."+name+"-*")
+	if err != nil {
+		return fmt.Errorf("creating snapshot file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Latest returns the most recent snapshot or
+// nil if the store does not contain any.
+func (s *SnapshotStore) Latest() (*Snapshot, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("listing snapshots: %w", err)
+	}
+
+	var names []string
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || filepath.Ext(name) != snapshotExt {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	sort.Strings(names)
+
+	latest := filepath.Join(s.dir, names[len(names)-1])
+
+	data, err := os.ReadFile(latest)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot %q: %w", latest, err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("unmarshalling snapshot %q: %w", latest, err)
+	}
+
+	if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, snap.Version)
+	}
+
+	return &snap, nil
+}
+
+// LoadReportFixture reads a report to render templates against
+// without querying JIRA. The file is either a snapshot saved by
+// SnapshotStore, the JSON output of the structured writer or a
+// bare Report in the same JSON encoding as snapshots.
+func LoadReportFixture(path string) (Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Report{}, fmt.Errorf("reading fixture %q: %w", path, err)
+	}
+
+	var schema struct {
+		SchemaVersion string `json:"schemaVersion"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return Report{}, fmt.Errorf("unmarshalling fixture %q: %w", path, err)
+	}
+
+	if schema.SchemaVersion != "" {
+		return loadReportDocument(path, data, schema.SchemaVersion)
+	}
+
+	var snap struct {
+		Version int     `json:"version"`
+		Report  *Report `json:"report"`
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return Report{}, fmt.Errorf("unmarshalling fixture %q: %w", path, err)
+	}
+
+	if snap.Report != nil {
+		if snap.Version != snapshotVersion {
+			return Report{}, fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, snap.Version)
+		}
+
+		return *snap.Report, nil
+	}
+
+	var rpt Report
+	if err := json.Unmarshal(data, &rpt); err != nil {
+		return Report{}, fmt.Errorf("unmarshalling fixture %q: %w", path, err)
+	}
+
+	return rpt, nil
+}
+
+func loadReportDocument(path string, data []byte, schemaVersion string) (Report, error) {
+	if schemaVersion != ReportSchemaVersion {
+		return Report{}, fmt.Errorf(
+			"%w %q in fixture %q, expected %q", ErrUnsupportedReportSchema, schemaVersion, path, ReportSchemaVersion,
+		)
+	}
+
+	var doc ReportDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Report{}, fmt.Errorf("unmarshalling fixture %q: %w", path, err)
+	}
+
+	rpt, err := doc.Report()
+	if err != nil {
+		return Report{}, fmt.Errorf("loading fixture %q: %w", path, err)
+	}
+
+	return rpt, nil
+}
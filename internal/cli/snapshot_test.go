@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestSnapshotStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewSnapshotStore(filepath.Join(t.TempDir(), "snapshots"))
+
+	latest, err := store.Latest()
+	require.NoError(t, err)
+	assert.Nil(t, latest, "empty store has no snapshots")
+
+	first := time.Date(2023, time.January, 19, 6, 1, 0, 0, time.UTC)
+	second := first.Add(7 * 24 * time.Hour)
+
+	require.NoError(t, store.Save(NewReport("first"), first))
+	require.NoError(t, store.Save(NewReport("second"), second))
+
+	latest, err = store.Latest()
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+
+	assert.Equal(t, "second", latest.Report.Title)
+	assert.True(t, second.Equal(latest.TakenAt))
+}
+
+func TestSnapshotStore_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "snapshot-20230101T000000Z.json"),
+		[]byte(`{"version": 99}`), 0o600,
+	))
+
+	_, err := NewSnapshotStore(dir).Latest()
+	require.ErrorIs(t, err, ErrUnsupportedSnapshotVersion)
+}
+
+func TestApplyChanges(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time {
+		return time.Date(2023, time.February, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	prev := Snapshot{
+		TakenAt: day(1),
+		Report: NewReport("title", Group{
+			Title: "APAC",
+			Issues: []jira.Issue{
+				{Key: "SDE-1", Color: jira.ColorGreen, TargetEnd: "2023-02-10", TargetEndDate: day(10)},
+				{Key: "SDE-2", Color: jira.ColorGreen, StatusComment: "old"},
+				{Key: "SDE-3"},
+			},
+		}),
+	}
+
+	rpt := NewReport("title",
+		Group{
+			Title: "APAC",
+			Issues: []jira.Issue{
+				{Key: "SDE-1", Color: jira.ColorRed, TargetEnd: "2023-02-17", TargetEndDate: day(17)},
+				{Key: "SDE-2", Color: jira.ColorGreen, StatusComment: "new"},
+				{Key: "SDE-4"},
+			},
+		},
+		Group{Title: "EMEA"},
+	)
+
+	ApplyChanges(&rpt, prev)
+
+	require.NotNil(t, rpt.Groups[0].Changes)
+	assert.Nil(t, rpt.Groups[1].Changes, "groups missing from the snapshot are not compared")
+
+	changes := rpt.Groups[0].Changes
+	assert.Equal(t, []string{"SDE-4"}, issueKeys(changes.Added))
+	assert.Equal(t, []string{"SDE-3"}, issueKeys(changes.Removed))
+	assert.Equal(t, []string{"SDE-1"}, changeKeys(changes.ColorChanges))
+	assert.Equal(t, []string{"SDE-1"}, changeKeys(changes.TargetEndSlips))
+	assert.Equal(t, []string{"SDE-2"}, changeKeys(changes.NewComments))
+}
+
+func changeKeys(changes []IssueChange) []string {
+	keys := make([]string, 0, len(changes))
+	for _, c := range changes {
+		keys = append(keys, c.Issue.Key)
+	}
+
+	return keys
+}
+
+func TestLoadReportFixture(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	takenAt := time.Date(2023, time.January, 19, 6, 1, 0, 0, time.UTC)
+	require.NoError(t, NewSnapshotStore(dir).Save(NewReport("snapshot", Group{
+		Title:  "NASA",
+		Issues: []jira.Issue{{Key: "SDE-1", Color: jira.ColorRed}},
+	}), takenAt))
+
+	bare := filepath.Join(dir, "report.json")
+	require.NoError(t, os.WriteFile(bare, []byte(`{"Title": "bare", "WeekOfYear": "3"}`), 0o600))
+
+	for name, tc := range map[string]struct {
+		Path          string
+		ExpectedTitle string
+	}{
+		"snapshot": {
+			Path:          filepath.Join(dir, "snapshot-20230119T060100Z.json"),
+			ExpectedTitle: "snapshot",
+		},
+		"bare report": {
+			Path:          bare,
+			ExpectedTitle: "bare",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rpt, err := LoadReportFixture(tc.Path)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedTitle, rpt.Title)
+		})
+	}
+
+	rpt, err := LoadReportFixture(filepath.Join(dir, "snapshot-20230119T060100Z.json"))
+	require.NoError(t, err)
+	require.Len(t, rpt.Groups, 1)
+	assert.Equal(t, jira.ColorRed, rpt.Groups[0].Issues[0].Color)
+}
+
+func TestLoadReportFixture_StructuredReport(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2023, time.January, 20, 0, 0, 0, 0, time.UTC)
+	issues := []jira.Issue{
+		{
+			Key:           "SDE-1",
+			Color:         jira.ColorRed,
+			Status:        "In Progress",
+			Summary:       "Test",
+			StatusComment: "blocked",
+			TargetEnd:     "2023-01-20",
+			TargetEndDate: day,
+			Overdue:       true,
+			DueInDays:     -4,
+			Updated:       day,
+			Fields:        map[string]jira.FieldValue{"assignee": {Raw: "Jane Doe"}},
+			History: []jira.HistoryEntry{
+				{Field: jira.FieldColor, At: day, Author: "Jane Doe", From: "Green", To: "Red"},
+			},
+		},
+		{Key: "SDE-2", Color: jira.ColorGreen, Status: "New", Summary: "Test 2"},
+	}
+
+	group := NewGroup("NASA", issues, DefaultSortBy, GroupByColor, jira.DefaultColorScale())
+	group.Completed = []jira.Issue{{Key: "SDE-3", Summary: "Done", Resolution: "Done", ResolutionDate: day}}
+	group.Changes = &Changes{
+		Since:        "Thu 19 Jan",
+		Added:        []jira.Issue{issues[1]},
+		Removed:      []jira.Issue{{Key: "SDE-4"}},
+		ColorChanges: []IssueChange{{Issue: issues[0], Previous: jira.Issue{Key: "SDE-1", Color: jira.ColorGreen}}},
+	}
+
+	rpt := NewReport("report", group)
+
+	var structured bytes.Buffer
+	require.NoError(t, NewStructuredReportWriter(&structured).WriteReport(rpt))
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, os.WriteFile(path, structured.Bytes(), 0o600))
+
+	loaded, err := LoadReportFixture(path)
+	require.NoError(t, err)
+
+	render := func(rpt Report) string {
+		var buf bytes.Buffer
+
+		rw, err := NewTemplatedReportWriter(&buf, WithFormat(FormatMarkdown))
+		require.NoError(t, err)
+		require.NoError(t, rw.WriteReport(rpt))
+
+		return buf.String()
+	}
+
+	assert.Equal(t, render(rpt), render(loaded))
+
+	var restructured bytes.Buffer
+	require.NoError(t, NewStructuredReportWriter(&restructured).WriteReport(loaded))
+	assert.JSONEq(t, structured.String(), restructured.String())
+}
+
+func TestLoadReportFixture_UnsupportedSchema(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schemaVersion": "jira-wrangler.report/v2"}`), 0o600))
+
+	_, err := LoadReportFixture(path)
+	require.ErrorIs(t, err, ErrUnsupportedReportSchema)
+	assert.Contains(t, err.Error(), ReportSchemaVersion)
+}
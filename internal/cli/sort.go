@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+	"golang.org/x/exp/slices"
+)
+
+type SortField string
+
+const (
+	SortFieldColor     SortField = "color"
+	SortFieldPriority  SortField = "priority"
+	SortFieldTargetEnd SortField = "targetEnd"
+	SortFieldStatus    SortField = "status"
+	SortFieldKey       SortField = "key"
+	SortFieldUpdated   SortField = "updated"
+)
+
+var (
+	ErrUnknownSortField = errors.New("unknown sort field")
+	ErrUnknownSortOrder = errors.New("unknown sort order")
+	ErrUnknownGroupBy   = errors.New("unknown groupBy")
+)
+
+// DefaultSortBy orders issues by color
+// on top of the order returned by JIRA.
+var DefaultSortBy = []SortKey{{Field: SortFieldColor}}
+
+// SortKey is a single key issues are sorted by.
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// UnmarshalJSON accepts either a string of the form "<field> [asc|desc]"
+// or an object with the keys "field" and "order".
+func (k *SortKey) UnmarshalJSON(data []byte) error {
+	var short string
+	if err := json.Unmarshal(data, &short); err == nil {
+		parts := strings.Fields(short)
+		switch len(parts) {
+		case 1:
+			return k.set(parts[0], "")
+		case 2:
+			return k.set(parts[0], parts[1])
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownSortField, short)
+		}
+	}
+
+	var obj struct {
+		Field string `json:"field"`
+		Order string `json:"order"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	return k.set(obj.Field, obj.Order)
+}
+
+func (k *SortKey) set(field, order string) error {
+	switch f := SortField(field); f {
+	case SortFieldColor, SortFieldPriority, SortFieldTargetEnd,
+		SortFieldStatus, SortFieldKey, SortFieldUpdated:
+		k.Field = f
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownSortField, field)
+	}
+
+	switch strings.ToLower(order) {
+	case "", "asc":
+		k.Descending = false
+	case "desc":
+		k.Descending = true
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownSortOrder, order)
+	}
+
+	return nil
+}
+
+// SortIssues stable sorts issues by the given keys in order,
+// ordering colors by their position in colors.
+// Issues without a target end or update time are always
+// placed last, regardless of the sort direction.
+func SortIssues(issues []jira.Issue, keys []SortKey, colors jira.ColorScale) {
+	slices.SortStableFunc(issues, func(a, b jira.Issue) bool {
+		for _, key := range keys {
+			if c := compareIssues(a, b, key, colors); c != 0 {
+				return c < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func compareIssues(a, b jira.Issue, key SortKey, colors jira.ColorScale) int {
+	var c int
+
+	switch key.Field {
+	case SortFieldColor:
+		c = compareBy(a.Color, b.Color, colors.Less)
+	case SortFieldPriority:
+		c = compareInts(priorityRank(a.Priority), priorityRank(b.Priority))
+	case SortFieldTargetEnd:
+		if c, ok := compareEmpty(a.TargetEndDate.IsZero(), b.TargetEndDate.IsZero()); ok {
+			return c
+		}
+
+		c = compareBy(a.TargetEndDate, b.TargetEndDate, time.Time.Before)
+	case SortFieldStatus:
+		c = strings.Compare(a.Status, b.Status)
+	case SortFieldKey:
+		c = compareKeys(a.Key, b.Key)
+	case SortFieldUpdated:
+		if c, ok := compareEmpty(a.Updated.IsZero(), b.Updated.IsZero()); ok {
+			return c
+		}
+
+		c = compareBy(a.Updated, b.Updated, time.Time.Before)
+	}
+
+	if key.Descending {
+		return -c
+	}
+
+	return c
+}
+
+func compareBy[T any](a, b T, less func(T, T) bool) int {
+	switch {
+	case less(a, b):
+		return -1
+	case less(b, a):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareEmpty orders empty values after non-empty ones.
+// The second return value is false if neither value is empty.
+func compareEmpty(aEmpty, bEmpty bool) (int, bool) {
+	switch {
+	case aEmpty && bEmpty:
+		return 0, true
+	case aEmpty:
+		return 1, true
+	case bEmpty:
+		return -1, true
+	default:
+		return 0, false
+	}
+}
+
+// compareKeys orders issue keys by project and
+// then numerically by their issue number.
+func compareKeys(a, b string) int {
+	aProject, aNum := splitKey(a)
+	bProject, bNum := splitKey(b)
+
+	if c := strings.Compare(aProject, bProject); c != 0 {
+		return c
+	}
+
+	return compareInts(aNum, bNum)
+}
+
+func splitKey(key string) (string, int) {
+	i := strings.LastIndex(key, "-")
+	if i < 0 {
+		return key, 0
+	}
+
+	var num int
+	if _, err := fmt.Sscan(key[i+1:], &num); err != nil {
+		return key, 0
+	}
+
+	return key[:i], num
+}
+
+var _priorityRanks = map[string]int{
+	"trivial":  1,
+	"minor":    2,
+	"low":      2,
+	"normal":   3,
+	"medium":   3,
+	"major":    4,
+	"high":     4,
+	"critical": 5,
+	"highest":  5,
+	"blocker":  6,
+}
+
+// priorityRank returns a rank increasing with the
+// importance of the priority so that sorting in
+// descending order lists the most important first.
+func priorityRank(priority string) int {
+	return _priorityRanks[strings.ToLower(priority)]
+}
+
+type GroupBy string
+
+const (
+	GroupByNone     GroupBy = ""
+	GroupByColor    GroupBy = "color"
+	GroupByStatus   GroupBy = "status"
+	GroupByPriority GroupBy = "priority"
+)
+
+func (g GroupBy) Validate() error {
+	switch g {
+	case GroupByNone, GroupByColor, GroupByStatus, GroupByPriority:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownGroupBy, string(g))
+	}
+}
+
+// GroupIssues splits the given issues into sub-groups in order
+// of their first appearance. The issues are expected to be sorted.
+// Color groups are titled with their label in colors.
+func GroupIssues(issues []jira.Issue, by GroupBy, colors jira.ColorScale) []SubGroup {
+	if by == GroupByNone {
+		return nil
+	}
+
+	var (
+		groups []SubGroup
+		index  = map[string]int{}
+	)
+
+	for _, issue := range issues {
+		var title string
+
+		switch by {
+		case GroupByColor:
+			title = colors.Label(issue.Color)
+		case GroupByStatus:
+			title = issue.Status
+		case GroupByPriority:
+			title = issue.Priority
+		}
+
+		i, ok := index[title]
+		if !ok {
+			i = len(groups)
+			index[title] = i
+
+			groups = append(groups, SubGroup{Title: title})
+		}
+
+		groups[i].Issues = append(groups[i].Issues, issue)
+	}
+
+	return groups
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestSortKey_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Input       string
+		Expected    SortKey
+		ExpectedErr error
+	}{
+		"field only": {
+			Input:    `"color"`,
+			Expected: SortKey{Field: SortFieldColor},
+		},
+		"field and direction": {
+			Input:    `"targetEnd desc"`,
+			Expected: SortKey{Field: SortFieldTargetEnd, Descending: true},
+		},
+		"object": {
+			Input:    `{"field":"updated","order":"DESC"}`,
+			Expected: SortKey{Field: SortFieldUpdated, Descending: true},
+		},
+		"unknown field": {
+			Input:       `"assignee"`,
+			ExpectedErr: ErrUnknownSortField,
+		},
+		"unknown order": {
+			Input:       `"key sideways"`,
+			ExpectedErr: ErrUnknownSortOrder,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var key SortKey
+
+			err := json.Unmarshal([]byte(tc.Input), &key)
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Expected, key)
+		})
+	}
+}
+
+func TestSortIssues(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time {
+		return time.Date(2023, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	issues := []jira.Issue{
+		{Key: "SDE-10", Color: jira.ColorGreen, Priority: "Major", TargetEndDate: day(5), Updated: day(3)},
+		{Key: "SDE-2", Color: jira.ColorRed, Priority: "Minor", Updated: day(1)},
+		{Key: "SDE-3", Color: jira.ColorRed, Priority: "Blocker", TargetEndDate: day(9), Updated: day(2)},
+		{Key: "SDE-1", Color: jira.ColorRed, Priority: "Normal", TargetEndDate: day(2)},
+	}
+
+	for name, tc := range map[string]struct {
+		Keys     []SortKey
+		Colors   jira.ColorScale
+		Expected []string
+	}{
+		"reds first then by due date": {
+			Keys: []SortKey{
+				{Field: SortFieldColor},
+				{Field: SortFieldTargetEnd},
+			},
+			Expected: []string{"SDE-1", "SDE-3", "SDE-2", "SDE-10"},
+		},
+		"custom color scale": {
+			Keys:     []SortKey{{Field: SortFieldColor}, {Field: SortFieldKey}},
+			Colors:   jira.ColorScale{{Value: "Green"}, {Value: "Red"}},
+			Expected: []string{"SDE-10", "SDE-1", "SDE-2", "SDE-3"},
+		},
+		"most important priority first": {
+			Keys:     []SortKey{{Field: SortFieldPriority, Descending: true}},
+			Expected: []string{"SDE-3", "SDE-10", "SDE-1", "SDE-2"},
+		},
+		"key numerically": {
+			Keys:     []SortKey{{Field: SortFieldKey}},
+			Expected: []string{"SDE-1", "SDE-2", "SDE-3", "SDE-10"},
+		},
+		"recently updated first with unknown last": {
+			Keys:     []SortKey{{Field: SortFieldUpdated, Descending: true}},
+			Expected: []string{"SDE-10", "SDE-3", "SDE-2", "SDE-1"},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			colors := tc.Colors
+			if colors == nil {
+				colors = jira.DefaultColorScale()
+			}
+
+			sorted := append([]jira.Issue(nil), issues...)
+			SortIssues(sorted, tc.Keys, colors)
+
+			keys := make([]string, 0, len(sorted))
+			for _, issue := range sorted {
+				keys = append(keys, issue.Key)
+			}
+
+			assert.Equal(t, tc.Expected, keys)
+		})
+	}
+}
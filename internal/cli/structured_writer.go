@@ -0,0 +1,514 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+	"sigs.k8s.io/yaml"
+)
+
+// ReportSchemaVersion identifies the layout of ReportDocument.
+// It changes whenever fields are removed or change their meaning,
+// adding optional fields does not bump the version.
+const ReportSchemaVersion = "jira-wrangler.report/v1"
+
+func NewStructuredReportWriter(out io.Writer, opts ...StructuredReportWriterOption) *StructuredReportWriter {
+	var cfg StructuredReportWriterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &StructuredReportWriter{
+		cfg: cfg,
+		out: out,
+	}
+}
+
+// StructuredReportWriter serializes reports as ReportDocument
+// in JSON or YAML for consumption by other tools.
+type StructuredReportWriter struct {
+	cfg StructuredReportWriterConfig
+	out io.Writer
+}
+
+func (w *StructuredReportWriter) WriteReport(rpt Report) error {
+	doc := NewReportDocument(rpt, w.cfg.JiraURL, w.cfg.Colors)
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling report: %w", err)
+	}
+
+	switch w.cfg.Format {
+	case FormatJSON:
+		data = append(data, '\n')
+	case FormatYAML:
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return fmt.Errorf("converting report to YAML: %w", err)
+		}
+	default:
+		return fmt.Errorf("%w: %q is not a structured format", ErrUnknownFormat, w.cfg.Format)
+	}
+
+	if _, err := w.out.Write(data); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	return nil
+}
+
+type StructuredReportWriterConfig struct {
+	// Format is either json or yaml. Defaults to json.
+	Format Format
+	// JiraURL is the base URL issue links are built from.
+	// Issues are written without links if empty.
+	JiraURL string
+	// Colors is the scale colors are labeled with.
+	Colors jira.ColorScale
+}
+
+func (c *StructuredReportWriterConfig) Option(opts ...StructuredReportWriterOption) {
+	for _, opt := range opts {
+		opt.ConfigureStructuredReportWriter(c)
+	}
+}
+
+func (c *StructuredReportWriterConfig) Default() {
+	if c.Format == "" {
+		c.Format = FormatJSON
+	}
+
+	if len(c.Colors) == 0 {
+		c.Colors = jira.DefaultColorScale()
+	}
+}
+
+type StructuredReportWriterOption interface {
+	ConfigureStructuredReportWriter(*StructuredReportWriterConfig)
+}
+
+// ReportDocument is the machine-readable representation of a Report.
+type ReportDocument struct {
+	// SchemaVersion is always ReportSchemaVersion.
+	SchemaVersion string `json:"schemaVersion"`
+	Title         string `json:"title"`
+	// GeneratedAt is an RFC 3339 timestamp.
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Week is the ISO week number the report was generated in.
+	Week   int             `json:"week"`
+	Groups []GroupDocument `json:"groups"`
+}
+
+type GroupDocument struct {
+	Title  string          `json:"title"`
+	Issues []IssueDocument `json:"issues"`
+	// SubGroups partitions Issues by key if the report is grouped.
+	SubGroups []SubGroupDocument `json:"subGroups,omitempty"`
+	// Completed lists recently resolved issues if enabled.
+	Completed []IssueDocument `json:"completed,omitempty"`
+	// Changes is only set when compared to a previous snapshot.
+	Changes *ChangesDocument `json:"changes,omitempty"`
+}
+
+type SubGroupDocument struct {
+	Title     string   `json:"title"`
+	IssueKeys []string `json:"issueKeys"`
+}
+
+type IssueDocument struct {
+	Key           string        `json:"key"`
+	URL           string        `json:"url,omitempty"`
+	Summary       string        `json:"summary"`
+	Status        string        `json:"status"`
+	Priority      string        `json:"priority,omitempty"`
+	Color         ColorDocument `json:"color"`
+	StatusComment string        `json:"statusComment,omitempty"`
+	// TargetEnd is the raw value of the target end field.
+	TargetEnd string `json:"targetEnd,omitempty"`
+	// TargetEndDate is formatted as YYYY-MM-DD. It and the
+	// due fields are omitted if TargetEnd is not a valid date.
+	TargetEndDate  string                   `json:"targetEndDate,omitempty"`
+	Overdue        bool                     `json:"overdue,omitempty"`
+	DueInDays      *int                     `json:"dueInDays,omitempty"`
+	DueThisWeek    bool                     `json:"dueThisWeek,omitempty"`
+	Updated        *time.Time               `json:"updated,omitempty"`
+	Resolution     string                   `json:"resolution,omitempty"`
+	ResolutionDate *time.Time               `json:"resolutionDate,omitempty"`
+	Fields         map[string]FieldDocument `json:"fields,omitempty"`
+	History        []HistoryDocument        `json:"history,omitempty"`
+}
+
+// ColorDocument holds the raw color value, empty for
+// issues without a color, and its configured label.
+type ColorDocument struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// FieldDocument holds an extra field as rendered in
+// templates and as returned by the JIRA API.
+type FieldDocument struct {
+	Text string `json:"text"`
+	Raw  any    `json:"raw"`
+}
+
+type HistoryDocument struct {
+	Field  string    `json:"field"`
+	At     time.Time `json:"at"`
+	Author string    `json:"author,omitempty"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+}
+
+// ChangesDocument references issues of the group by key.
+type ChangesDocument struct {
+	Since          string           `json:"since"`
+	Added          []string         `json:"added,omitempty"`
+	Removed        []string         `json:"removed,omitempty"`
+	ColorChanges   []ChangeDocument `json:"colorChanges,omitempty"`
+	TargetEndSlips []ChangeDocument `json:"targetEndSlips,omitempty"`
+	NewComments    []ChangeDocument `json:"newComments,omitempty"`
+}
+
+type ChangeDocument struct {
+	Key  string `json:"key"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// NewReportDocument converts rpt into a ReportDocument
+// linking issues to jiraURL unless it is empty and
+// labeling colors according to colors.
+func NewReportDocument(rpt Report, jiraURL string, colors jira.ColorScale) ReportDocument {
+	week, _ := strconv.Atoi(rpt.WeekOfYear)
+
+	doc := ReportDocument{
+		SchemaVersion: ReportSchemaVersion,
+		Title:         rpt.Title,
+		GeneratedAt:   rpt.GeneratedAt,
+		Week:          week,
+		Groups:        make([]GroupDocument, 0, len(rpt.Groups)),
+	}
+
+	for _, g := range rpt.Groups {
+		doc.Groups = append(doc.Groups, newGroupDocument(g, jiraURL, colors))
+	}
+
+	return doc
+}
+
+// Report converts the document back into a Report, e.g. to render
+// templates against the output of an earlier run. Issues of changes
+// which are no longer part of their group only retain their key.
+func (d ReportDocument) Report() (Report, error) {
+	rpt := Report{
+		Title:       d.Title,
+		GeneratedAt: d.GeneratedAt,
+		Now:         d.GeneratedAt.Format(time.RFC822),
+		WeekOfYear:  strconv.Itoa(d.Week),
+	}
+
+	for _, gd := range d.Groups {
+		g, err := gd.group()
+		if err != nil {
+			return Report{}, fmt.Errorf("group %q: %w", gd.Title, err)
+		}
+
+		rpt.Groups = append(rpt.Groups, g)
+	}
+
+	return rpt, nil
+}
+
+func (d GroupDocument) group() (Group, error) {
+	issues, err := issuesFromDocuments(d.Issues)
+	if err != nil {
+		return Group{}, err
+	}
+
+	completed, err := issuesFromDocuments(d.Completed)
+	if err != nil {
+		return Group{}, err
+	}
+
+	byKey := make(map[string]jira.Issue, len(issues))
+	for _, i := range issues {
+		byKey[i.Key] = i
+	}
+
+	lookup := func(key string) jira.Issue {
+		if i, ok := byKey[key]; ok {
+			return i
+		}
+
+		return jira.Issue{Key: key}
+	}
+
+	g := Group{
+		Title:     d.Title,
+		Issues:    issues,
+		Completed: completed,
+	}
+
+	for _, sg := range d.SubGroups {
+		sub := SubGroup{Title: sg.Title}
+		for _, key := range sg.IssueKeys {
+			sub.Issues = append(sub.Issues, lookup(key))
+		}
+
+		g.SubGroups = append(g.SubGroups, sub)
+	}
+
+	if d.Changes != nil {
+		changes := &Changes{Since: d.Changes.Since}
+
+		for _, key := range d.Changes.Added {
+			changes.Added = append(changes.Added, lookup(key))
+		}
+
+		for _, key := range d.Changes.Removed {
+			changes.Removed = append(changes.Removed, lookup(key))
+		}
+
+		changes.ColorChanges = changesFromDocuments(d.Changes.ColorChanges, lookup, func(i *jira.Issue, v string) {
+			i.Color = jira.Color(v)
+		})
+		changes.TargetEndSlips = changesFromDocuments(d.Changes.TargetEndSlips, lookup, func(i *jira.Issue, v string) {
+			i.TargetEnd = v
+		})
+		changes.NewComments = changesFromDocuments(d.Changes.NewComments, lookup, func(i *jira.Issue, v string) {
+			i.StatusComment = v
+		})
+
+		g.Changes = changes
+	}
+
+	return g, nil
+}
+
+func issuesFromDocuments(docs []IssueDocument) ([]jira.Issue, error) {
+	var issues []jira.Issue
+
+	for _, doc := range docs {
+		i, err := doc.issue()
+		if err != nil {
+			return nil, fmt.Errorf("issue %q: %w", doc.Key, err)
+		}
+
+		issues = append(issues, i)
+	}
+
+	return issues, nil
+}
+
+func (d IssueDocument) issue() (jira.Issue, error) {
+	i := jira.Issue{
+		Key:           d.Key,
+		Summary:       d.Summary,
+		Status:        d.Status,
+		Priority:      d.Priority,
+		Color:         jira.Color(d.Color.Value),
+		StatusComment: d.StatusComment,
+		TargetEnd:     d.TargetEnd,
+		Overdue:       d.Overdue,
+		DueThisWeek:   d.DueThisWeek,
+		Resolution:    d.Resolution,
+	}
+
+	if d.TargetEndDate != "" {
+		date, err := time.Parse(DefaultDateLayout, d.TargetEndDate)
+		if err != nil {
+			return jira.Issue{}, fmt.Errorf("parsing targetEndDate: %w", err)
+		}
+
+		i.TargetEndDate = date
+	}
+
+	if d.DueInDays != nil {
+		i.DueInDays = *d.DueInDays
+	}
+
+	if d.Updated != nil {
+		i.Updated = *d.Updated
+	}
+
+	if d.ResolutionDate != nil {
+		i.ResolutionDate = *d.ResolutionDate
+	}
+
+	if len(d.Fields) > 0 {
+		i.Fields = make(map[string]jira.FieldValue, len(d.Fields))
+
+		for name, f := range d.Fields {
+			i.Fields[name] = jira.FieldValue{Raw: f.Raw}
+		}
+	}
+
+	for _, h := range d.History {
+		i.History = append(i.History, jira.HistoryEntry{
+			Field:  h.Field,
+			At:     h.At,
+			Author: h.Author,
+			From:   h.From,
+			To:     h.To,
+		})
+	}
+
+	return i, nil
+}
+
+// changesFromDocuments pairs the current issues with copies
+// holding the previous value set through setValue.
+func changesFromDocuments(
+	docs []ChangeDocument, lookup func(key string) jira.Issue, setValue func(i *jira.Issue, v string),
+) []IssueChange {
+	var changes []IssueChange
+
+	for _, doc := range docs {
+		c := IssueChange{Issue: lookup(doc.Key)}
+		c.Previous = c.Issue
+		setValue(&c.Previous, doc.From)
+		setValue(&c.Issue, doc.To)
+
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+func newGroupDocument(g Group, jiraURL string, colors jira.ColorScale) GroupDocument {
+	doc := GroupDocument{
+		Title:  g.Title,
+		Issues: newIssueDocuments(g.Issues, jiraURL, colors),
+	}
+
+	if len(g.Completed) > 0 {
+		doc.Completed = newIssueDocuments(g.Completed, jiraURL, colors)
+	}
+
+	for _, sg := range g.SubGroups {
+		doc.SubGroups = append(doc.SubGroups, SubGroupDocument{
+			Title:     sg.Title,
+			IssueKeys: issueKeys(sg.Issues),
+		})
+	}
+
+	if g.Changes != nil {
+		doc.Changes = &ChangesDocument{
+			Since:   g.Changes.Since,
+			Added:   issueKeys(g.Changes.Added),
+			Removed: issueKeys(g.Changes.Removed),
+			ColorChanges: newChangeDocuments(g.Changes.ColorChanges, func(i jira.Issue) string {
+				return i.Color.String()
+			}),
+			TargetEndSlips: newChangeDocuments(g.Changes.TargetEndSlips, func(i jira.Issue) string {
+				return i.TargetEnd
+			}),
+			NewComments: newChangeDocuments(g.Changes.NewComments, func(i jira.Issue) string {
+				return i.StatusComment
+			}),
+		}
+	}
+
+	return doc
+}
+
+func newIssueDocuments(issues []jira.Issue, jiraURL string, colors jira.ColorScale) []IssueDocument {
+	docs := make([]IssueDocument, 0, len(issues))
+
+	for _, i := range issues {
+		docs = append(docs, newIssueDocument(i, jiraURL, colors))
+	}
+
+	return docs
+}
+
+func newIssueDocument(i jira.Issue, jiraURL string, colors jira.ColorScale) IssueDocument {
+	doc := IssueDocument{
+		Key:      i.Key,
+		Summary:  i.Summary,
+		Status:   i.Status,
+		Priority: i.Priority,
+		Color: ColorDocument{
+			Value: i.Color.String(),
+			Label: colors.Label(i.Color),
+		},
+		StatusComment:  i.StatusComment,
+		TargetEnd:      i.TargetEnd,
+		Updated:        optionalTime(i.Updated),
+		Resolution:     i.Resolution,
+		ResolutionDate: optionalTime(i.ResolutionDate),
+	}
+
+	if jiraURL != "" {
+		doc.URL = issueURL(jiraURL, i.Key)
+	}
+
+	if !i.TargetEndDate.IsZero() {
+		dueInDays := i.DueInDays
+
+		doc.TargetEndDate = i.TargetEndDate.Format(DefaultDateLayout)
+		doc.Overdue = i.Overdue
+		doc.DueInDays = &dueInDays
+		doc.DueThisWeek = i.DueThisWeek
+	}
+
+	if len(i.Fields) > 0 {
+		doc.Fields = make(map[string]FieldDocument, len(i.Fields))
+
+		for name, v := range i.Fields {
+			doc.Fields[name] = FieldDocument{
+				Text: v.String(),
+				Raw:  v.Raw,
+			}
+		}
+	}
+
+	for _, h := range i.History {
+		doc.History = append(doc.History, HistoryDocument{
+			Field:  h.Field,
+			At:     h.At,
+			Author: h.Author,
+			From:   h.From,
+			To:     h.To,
+		})
+	}
+
+	return doc
+}
+
+func newChangeDocuments(changes []IssueChange, value func(jira.Issue) string) []ChangeDocument {
+	var docs []ChangeDocument
+
+	for _, c := range changes {
+		docs = append(docs, ChangeDocument{
+			Key:  c.Issue.Key,
+			From: value(c.Previous),
+			To:   value(c.Issue),
+		})
+	}
+
+	return docs
+}
+
+func issueKeys(issues []jira.Issue) []string {
+	var keys []string
+
+	for _, i := range issues {
+		keys = append(keys, i.Key)
+	}
+
+	return keys
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
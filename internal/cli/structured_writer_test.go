@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetechnick/jira-wrangler/internal/jira"
+)
+
+func TestStructuredReportWriter_WriteReport(t *testing.T) {
+	t.Parallel()
+
+	generatedAt := time.Date(2023, time.January, 24, 12, 42, 0, 0, time.UTC)
+
+	rpt := Report{
+		Title:       "title",
+		WeekOfYear:  "4",
+		Now:         "24 Jan 23 12:42 UTC",
+		GeneratedAt: generatedAt,
+		Groups: []Group{
+			{
+				Title: "group",
+				Issues: []jira.Issue{
+					{
+						Key:           "SDE-1",
+						Color:         jira.ColorRed,
+						Status:        "In Progress",
+						Summary:       "summary",
+						StatusComment: "blocked",
+						TargetEnd:     "2023-01-20",
+						TargetEndDate: time.Date(2023, time.January, 20, 0, 0, 0, 0, time.UTC),
+						Overdue:       true,
+						DueInDays:     -4,
+						Updated:       generatedAt,
+						Fields: map[string]jira.FieldValue{
+							"assignee": {Raw: map[string]interface{}{"displayName": "Jane"}},
+						},
+					},
+				},
+				Changes: &Changes{
+					Since: "17 Jan 23 12:42 UTC",
+					ColorChanges: []IssueChange{
+						{
+							Issue:    jira.Issue{Key: "SDE-1", Color: jira.ColorRed},
+							Previous: jira.Issue{Key: "SDE-1", Color: jira.ColorGreen},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Format   Format
+		Expected string
+	}{
+		"json": {
+			Format: FormatJSON,
+			Expected: strings.Join([]string{
+				`{`,
+				`  "schemaVersion": "jira-wrangler.report/v1",`,
+				`  "title": "title",`,
+				`  "generatedAt": "2023-01-24T12:42:00Z",`,
+				`  "week": 4,`,
+				`  "groups": [`,
+				`    {`,
+				`      "title": "group",`,
+				`      "issues": [`,
+				`        {`,
+				`          "key": "SDE-1",`,
+				`          "url": "https://jira.example.com/browse/SDE-1",`,
+				`          "summary": "summary",`,
+				`          "status": "In Progress",`,
+				`          "color": {`,
+				`            "value": "Red",`,
+				`            "label": "Red"`,
+				`          },`,
+				`          "statusComment": "blocked",`,
+				`          "targetEnd": "2023-01-20",`,
+				`          "targetEndDate": "2023-01-20",`,
+				`          "overdue": true,`,
+				`          "dueInDays": -4,`,
+				`          "updated": "2023-01-24T12:42:00Z",`,
+				`          "fields": {`,
+				`            "assignee": {`,
+				`              "text": "Jane",`,
+				`              "raw": {`,
+				`                "displayName": "Jane"`,
+				`              }`,
+				`            }`,
+				`          }`,
+				`        }`,
+				`      ],`,
+				`      "changes": {`,
+				`        "since": "17 Jan 23 12:42 UTC",`,
+				`        "colorChanges": [`,
+				`          {`,
+				`            "key": "SDE-1",`,
+				`            "from": "Green",`,
+				`            "to": "Red"`,
+				`          }`,
+				`        ]`,
+				`      }`,
+				`    }`,
+				`  ]`,
+				`}`,
+				``,
+			}, "\n"),
+		},
+		"yaml": {
+			Format: FormatYAML,
+			Expected: strings.Join([]string{
+				`generatedAt: "2023-01-24T12:42:00Z"`,
+				`groups:`,
+				`- changes:`,
+				`    colorChanges:`,
+				`    - from: Green`,
+				`      key: SDE-1`,
+				`      to: Red`,
+				`    since: 17 Jan 23 12:42 UTC`,
+				`  issues:`,
+				`  - color:`,
+				`      label: Red`,
+				`      value: Red`,
+				`    dueInDays: -4`,
+				`    fields:`,
+				`      assignee:`,
+				`        raw:`,
+				`          displayName: Jane`,
+				`        text: Jane`,
+				`    key: SDE-1`,
+				`    overdue: true`,
+				`    status: In Progress`,
+				`    statusComment: blocked`,
+				`    summary: summary`,
+				`    targetEnd: "2023-01-20"`,
+				`    targetEndDate: "2023-01-20"`,
+				`    updated: "2023-01-24T12:42:00Z"`,
+				`    url: https://jira.example.com/browse/SDE-1`,
+				`  title: group`,
+				`schemaVersion: jira-wrangler.report/v1`,
+				`title: title`,
+				`week: 4`,
+				``,
+			}, "\n"),
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			rw := NewStructuredReportWriter(
+				&buf,
+				WithFormat(tc.Format),
+				WithJiraURL("https://jira.example.com"),
+			)
+
+			require.NoError(t, rw.WriteReport(rpt))
+
+			assert.Equal(t, tc.Expected, buf.String())
+		})
+	}
+}
+
+func TestStructuredReportWriter_WriteReport_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	rw := NewStructuredReportWriter(&bytes.Buffer{}, WithFormat(FormatHTML))
+
+	require.ErrorIs(t, rw.WriteReport(Report{}), ErrUnknownFormat)
+}
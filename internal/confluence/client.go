@@ -0,0 +1,259 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultConflictRetries = 3
+
+var (
+	ErrRequestFailed   = errors.New("confluence request failed")
+	ErrVersionConflict = errors.New("page was modified concurrently")
+)
+
+func NewClient(client *http.Client, opts ...ClientOption) (*Client, error) {
+	var cfg ClientConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	baseURL, err := url.Parse(strings.TrimSuffix(strings.TrimSpace(cfg.BaseURL), "/") + "/")
+	if err != nil {
+		return nil, fmt.Errorf("parsing base URL: %w", err)
+	}
+
+	return &Client{
+		c:       client,
+		cfg:     cfg,
+		baseURL: baseURL,
+	}, nil
+}
+
+// Client publishes pages through the Confluence Server REST API.
+type Client struct {
+	c       *http.Client
+	cfg     ClientConfig
+	baseURL *url.URL
+}
+
+// Page describes the desired state of a Confluence page.
+type Page struct {
+	SpaceKey string
+	// ParentID is the ID of the page the page is created below.
+	ParentID string
+	Title    string
+	// Body is the content of the page in storage format.
+	Body string
+}
+
+// PublishPage creates the page or replaces the content of the page with
+// the same title in the space and returns its ID. Updates rejected due to
+// a concurrent edit are retried on top of the latest version.
+func (c *Client) PublishPage(ctx context.Context, page Page) (string, error) {
+	for attempt := 0; ; attempt++ {
+		existing, err := c.findPage(ctx, page.SpaceKey, page.Title)
+		if err != nil {
+			return "", fmt.Errorf("looking up page %q: %w", page.Title, err)
+		}
+
+		if existing == nil {
+			created, err := c.createPage(ctx, page)
+			if err != nil {
+				return "", fmt.Errorf("creating page %q: %w", page.Title, err)
+			}
+
+			return created.ID, nil
+		}
+
+		err = c.updatePage(ctx, existing, page)
+		if errors.Is(err, ErrVersionConflict) && attempt < c.cfg.ConflictRetries {
+			continue
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("updating page %q: %w", page.Title, err)
+		}
+
+		return existing.ID, nil
+	}
+}
+
+func (c *Client) findPage(ctx context.Context, spaceKey, title string) (*content, error) {
+	q := url.Values{}
+	q.Set("type", "page")
+	q.Set("spaceKey", spaceKey)
+	q.Set("title", title)
+	q.Set("expand", "version")
+
+	var res struct {
+		Results []content `json:"results"`
+	}
+
+	if err := c.do(ctx, http.MethodGet, "rest/api/content?"+q.Encode(), nil, &res); err != nil {
+		return nil, err
+	}
+
+	if len(res.Results) == 0 {
+		return nil, nil
+	}
+
+	return &res.Results[0], nil
+}
+
+func (c *Client) createPage(ctx context.Context, page Page) (*content, error) {
+	var res content
+
+	if err := c.do(ctx, http.MethodPost, "rest/api/content", newContent(page), &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (c *Client) updatePage(ctx context.Context, existing *content, page Page) error {
+	var current int
+	if existing.Version != nil {
+		current = existing.Version.Number
+	}
+
+	update := newContent(page)
+	update.ID = existing.ID
+	update.Version = &version{Number: current + 1}
+
+	return c.do(ctx, http.MethodPut, "rest/api/content/"+url.PathEscape(existing.ID), update, nil)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	u, err := c.baseURL.Parse(path)
+	if err != nil {
+		return fmt.Errorf("building URL: %w", err)
+	}
+
+	var reqBody io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshalling request: %w", err)
+		}
+
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusConflict:
+		return ErrVersionConflict
+	case res.StatusCode < 200 || res.StatusCode > 299:
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+		return fmt.Errorf("%w: %s %s: %s: %s", ErrRequestFailed, method, u.Path, res.Status, strings.TrimSpace(string(msg)))
+	case out == nil:
+		return nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
+
+type content struct {
+	ID        string     `json:"id,omitempty"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Space     *space     `json:"space,omitempty"`
+	Ancestors []ancestor `json:"ancestors,omitempty"`
+	Body      *body      `json:"body,omitempty"`
+	Version   *version   `json:"version,omitempty"`
+}
+
+func newContent(page Page) content {
+	c := content{
+		Type:  "page",
+		Title: page.Title,
+		Space: &space{Key: page.SpaceKey},
+		Body: &body{
+			Storage: storage{
+				Value:          page.Body,
+				Representation: "storage",
+			},
+		},
+	}
+
+	if page.ParentID != "" {
+		c.Ancestors = []ancestor{{ID: page.ParentID}}
+	}
+
+	return c
+}
+
+type space struct {
+	Key string `json:"key"`
+}
+
+type ancestor struct {
+	ID string `json:"id"`
+}
+
+type body struct {
+	Storage storage `json:"storage"`
+}
+
+type storage struct {
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+type version struct {
+	Number int `json:"number"`
+}
+
+type ClientConfig struct {
+	BaseURL string
+	// ConflictRetries is the number of times an update is
+	// retried after a version conflict. Defaults to 3.
+	ConflictRetries int
+}
+
+func (c *ClientConfig) Option(opts ...ClientOption) {
+	for _, opt := range opts {
+		opt.ConfigureClient(c)
+	}
+}
+
+func (c *ClientConfig) Default() {
+	if c.ConflictRetries <= 0 {
+		c.ConflictRetries = defaultConflictRetries
+	}
+}
+
+type ClientOption interface {
+	ConfigureClient(*ClientConfig)
+}
@@ -0,0 +1,189 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_PublishPage(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Existing        *content
+		Conflicts       int
+		ExpectedVersion int
+		ExpectedErr     error
+	}{
+		"create": {
+			ExpectedVersion: 1,
+		},
+		"update": {
+			Existing:        &content{ID: "42", Title: "title", Version: &version{Number: 3}},
+			ExpectedVersion: 4,
+		},
+		"update after conflict": {
+			Existing:        &content{ID: "42", Title: "title", Version: &version{Number: 3}},
+			Conflicts:       2,
+			ExpectedVersion: 6,
+		},
+		"too many conflicts": {
+			Existing:    &content{ID: "42", Title: "title", Version: &version{Number: 3}},
+			Conflicts:   5,
+			ExpectedErr: ErrVersionConflict,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &fakeConfluence{Conflicts: tc.Conflicts}
+			if tc.Existing != nil {
+				fake.pages = map[string]content{tc.Existing.ID: *tc.Existing}
+			}
+
+			srv := httptest.NewServer(fake)
+			defer srv.Close()
+
+			c, err := NewClient(srv.Client(), WithBaseURL(srv.URL+"/confluence"))
+			require.NoError(t, err)
+
+			id, err := c.PublishPage(context.Background(), Page{
+				SpaceKey: "SDE",
+				ParentID: "1",
+				Title:    "title",
+				Body:     "<p>body</p>",
+			})
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			fake.lock.Lock()
+			defer fake.lock.Unlock()
+
+			page := fake.pages[id]
+			assert.Equal(t, tc.ExpectedVersion, page.Version.Number)
+			assert.Equal(t, "SDE", page.Space.Key)
+			assert.Equal(t, []ancestor{{ID: "1"}}, page.Ancestors)
+			assert.Equal(t, storage{Value: "<p>body</p>", Representation: "storage"}, page.Body.Storage)
+		})
+	}
+}
+
+func TestClient_PublishPage_Error(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.Client(), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	_, err = c.PublishPage(context.Background(), Page{SpaceKey: "SDE", Title: "title"})
+	require.ErrorIs(t, err, ErrRequestFailed)
+}
+
+// fakeConfluence stores pages in memory and rejects
+// the first Conflicts updates with a version conflict.
+type fakeConfluence struct {
+	Conflicts int
+
+	lock  sync.Mutex
+	pages map[string]content
+}
+
+func (f *fakeConfluence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.pages == nil {
+		f.pages = map[string]content{}
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/confluence")
+
+	switch {
+	case r.Method == http.MethodGet && path == "/rest/api/content":
+		res := struct {
+			Results []content `json:"results"`
+		}{Results: []content{}}
+
+		for _, p := range f.pages {
+			if p.Title == r.URL.Query().Get("title") {
+				res.Results = append(res.Results, p)
+			}
+		}
+
+		writeJSON(w, res)
+	case r.Method == http.MethodPost && path == "/rest/api/content":
+		var c content
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		c.ID = strconv.Itoa(100 + len(f.pages))
+		c.Version = &version{Number: 1}
+		f.pages[c.ID] = c
+
+		writeJSON(w, c)
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "/rest/api/content/"):
+		var c content
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		existing, ok := f.pages[c.ID]
+		if !ok || c.ID != strings.TrimPrefix(path, "/rest/api/content/") {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if f.Conflicts > 0 {
+			// Simulate a concurrent edit.
+			f.Conflicts--
+			existing.Version.Number++
+			f.pages[c.ID] = existing
+
+			http.Error(w, "version conflict", http.StatusConflict)
+
+			return
+		}
+
+		if c.Version.Number != existing.Version.Number+1 {
+			http.Error(w, "version conflict", http.StatusConflict)
+
+			return
+		}
+
+		f.pages[c.ID] = c
+
+		writeJSON(w, c)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,13 @@
+package confluence
+
+type WithBaseURL string
+
+func (w WithBaseURL) ConfigureClient(c *ClientConfig) {
+	c.BaseURL = string(w)
+}
+
+type WithConflictRetries int
+
+func (w WithConflictRetries) ConfigureClient(c *ClientConfig) {
+	c.ConflictRetries = int(w)
+}
@@ -2,23 +2,78 @@ package jira
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	jira "github.com/andygrunwald/go-jira/v2/onpremise"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 )
 
 const (
-	colorCustomFieldID         = "customfield_12320845"
-	targetEndDateCustomFieldID = "customfield_12313942"
-	reportCommentPrefix        = "[report]"
+	reportCommentPrefix = "[report]"
+	defaultPageSize     = 50
+	defaultWorkers      = 4
 )
 
+var ErrTooManyIssues = errors.New("too many issues")
+
 func NewClient(client *http.Client, opts ...ClientOption) (*Client, error) {
 	var cfg ClientConfig
 
 	cfg.Option(opts...)
+	cfg.Default()
+
+	switch {
+	case cfg.ReplayDir != "":
+		client = wrapTransport(client, func(http.RoundTripper) http.RoundTripper {
+			return &replayTransport{dir: cfg.ReplayDir}
+		})
+	case cfg.RecordDir != "":
+		client = wrapTransport(client, func(next http.RoundTripper) http.RoundTripper {
+			return &recordingTransport{dir: cfg.RecordDir, next: next}
+		})
+	}
+
+	if cfg.Metrics != nil {
+		metrics, err := newRequestMetrics(cfg.Metrics)
+		if err != nil {
+			return nil, err
+		}
+
+		client = wrapTransport(client, func(next http.RoundTripper) http.RoundTripper {
+			return &instrumentedTransport{metrics: metrics, next: next}
+		})
+	}
+
+	// Replayed responses are served from disk and never
+	// fail transiently, so neither limits nor retries apply.
+	if cfg.RequestsPerSecond > 0 && cfg.ReplayDir == "" {
+		limiter := rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.RequestBurst)
+
+		client = wrapTransport(client, func(next http.RoundTripper) http.RoundTripper {
+			return &rateLimitedTransport{limiter: limiter, next: next}
+		})
+	}
+
+	if cfg.Retry != nil && cfg.ReplayDir == "" {
+		retryCfg := *cfg.Retry
+		retryCfg.Default()
+
+		client = wrapTransport(client, func(next http.RoundTripper) http.RoundTripper {
+			return &retryTransport{cfg: retryCfg, next: next}
+		})
+	}
 
 	c, err := jira.NewClient(cfg.BaseURL, client)
 	if err != nil {
@@ -26,55 +81,349 @@ func NewClient(client *http.Client, opts ...ClientOption) (*Client, error) {
 	}
 
 	return &Client{
-		c: c,
+		c:   c,
+		cfg: cfg,
 	}, nil
 }
 
 type Client struct {
-	c *jira.Client
+	c   *jira.Client
+	cfg ClientConfig
+
+	fieldsLock sync.Mutex
+	fieldIDs   map[string]string
+	fieldNames map[string]string
 }
 
 func (c *Client) SearchIssues(ctx context.Context, jql string) ([]Issue, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	issues, _, err := c.c.Issue.Search(ctx, jql, &jira.SearchOptions{})
+	if err := c.ResolveFields(ctx); err != nil {
+		return nil, err
+	}
+
+	issues, err := c.search(ctx, jql)
 	if err != nil {
-		return nil, fmt.Errorf("querying JIRA for issues: %w", err)
+		return nil, err
 	}
 
-	res := make([]Issue, 0, len(issues))
-	for _, issue := range issues {
-		i, err := c.getIssue(ctx, issue.Key)
+	res := make([]Issue, len(issues))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(c.cfg.Workers)
+
+	for i, issue := range issues {
+		if !issue.commentsTruncated() {
+			res[i] = c.issueFromRaw(issue)
+
+			continue
+		}
+
+		i, key := i, issue.Issue.Key
+
+		g.Go(func() error {
+			issue, err := c.getIssue(ctx, key)
+			if err != nil {
+				return err
+			}
+
+			res[i] = issue
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CountIssues returns the number of issues matching jql without
+// fetching them. JIRA rejects invalid queries with an error.
+func (c *Client) CountIssues(ctx context.Context, jql string) (int, error) {
+	if err := c.ResolveFields(ctx); err != nil {
+		return 0, err
+	}
+
+	page, err := c.searchPage(ctx, jql, 0, 0)
+	if err != nil {
+		return 0, fmt.Errorf("querying JIRA for issues: %w", err)
+	}
+
+	return page.Total, nil
+}
+
+// search follows the result pages of the given JQL query until
+// all matching issues or the configured maximum have been fetched.
+func (c *Client) search(ctx context.Context, jql string) ([]rawIssue, error) {
+	pageSize := c.cfg.PageSize
+	if c.cfg.MaxIssues > 0 && c.cfg.MaxIssues < pageSize {
+		pageSize = c.cfg.MaxIssues
+	}
+
+	var (
+		res     []rawIssue
+		startAt int
+	)
+
+	for {
+		page, err := c.searchPage(ctx, jql, startAt, pageSize)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("querying JIRA for issues: %w", err)
+		}
+
+		if startAt == 0 && c.cfg.MaxIssues > 0 && page.Total > c.cfg.MaxIssues {
+			if err := c.handleTooManyIssues(jql, page.Total); err != nil {
+				return nil, err
+			}
 		}
 
-		res = append(res, i)
+		res = append(res, page.Issues...)
+
+		if c.cfg.MaxIssues > 0 && len(res) >= c.cfg.MaxIssues {
+			res = res[:c.cfg.MaxIssues]
+
+			break
+		}
+
+		if len(page.Issues) == 0 || page.StartAt+len(page.Issues) >= page.Total {
+			break
+		}
+
+		startAt = page.StartAt + len(page.Issues)
 	}
 
 	return res, nil
 }
 
+func (c *Client) searchPage(ctx context.Context, jql string, startAt, maxResults int) (*searchResult, error) {
+	q := url.Values{}
+	q.Set("jql", jql)
+	q.Set("startAt", strconv.Itoa(startAt))
+	q.Set("maxResults", strconv.Itoa(maxResults))
+	q.Set("fields", strings.Join(c.issueFields(), ","))
+
+	if expand := c.expand(); expand != "" {
+		q.Set("expand", expand)
+	}
+
+	u := url.URL{
+		Path:     "rest/api/2/search",
+		RawQuery: q.Encode(),
+	}
+
+	req, err := c.c.NewRequest(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating search request: %w", err)
+	}
+
+	var res searchResult
+
+	resp, err := c.c.Do(req, &res)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return &res, nil
+}
+
+// issueFields returns the issue fields needed to
+// build an Issue directly from search results.
+func (c *Client) issueFields() []string {
+	fields := []string{
+		"summary",
+		"status",
+		"priority",
+		"comment",
+		"updated",
+		"resolution",
+		"resolutiondate",
+		c.fieldID(FieldColor),
+		c.fieldID(FieldTargetEnd),
+	}
+
+	for _, name := range c.cfg.ExtraFields {
+		fields = append(fields, c.fieldIDOrName(name))
+	}
+
+	return fields
+}
+
+// expand returns the entities to expand in issue responses.
+func (c *Client) expand() string {
+	if c.cfg.HistoryWindow > 0 {
+		return "changelog"
+	}
+
+	return ""
+}
+
+type searchResult struct {
+	StartAt    int        `json:"startAt"`
+	MaxResults int        `json:"maxResults"`
+	Total      int        `json:"total"`
+	Issues     []rawIssue `json:"issues"`
+}
+
+// rawIssue wraps an issue returned by JIRA with the paging
+// information of its comments and the undecoded values of all
+// of its fields, which the upstream types discard.
+type rawIssue struct {
+	Issue         jira.Issue
+	CommentsTotal int
+	Fields        map[string]interface{}
+	Changelog     *rawChangelog
+}
+
+func (i *rawIssue) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &i.Issue); err != nil {
+		return err
+	}
+
+	var aux struct {
+		Fields    map[string]interface{} `json:"fields"`
+		Changelog *rawChangelog          `json:"changelog"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	i.Fields = aux.Fields
+	i.Changelog = aux.Changelog
+
+	if comment, ok := aux.Fields["comment"].(map[string]interface{}); ok {
+		if total, ok := comment["total"].(float64); ok {
+			i.CommentsTotal = int(total)
+		}
+	}
+
+	return nil
+}
+
+// commentsTruncated reports whether the search
+// returned only a subset of the issue's comments.
+func (i rawIssue) commentsTruncated() bool {
+	var n int
+	if i.Issue.Fields != nil && i.Issue.Fields.Comments != nil {
+		n = len(i.Issue.Fields.Comments.Comments)
+	}
+
+	return i.CommentsTotal > n
+}
+
+func (c *Client) handleTooManyIssues(jql string, total int) error {
+	err := fmt.Errorf(
+		"%w: query %q matched %d issues, limit is %d",
+		ErrTooManyIssues, jql, total, c.cfg.MaxIssues,
+	)
+
+	switch c.cfg.MaxIssuesPolicy {
+	case MaxIssuesPolicyFail:
+		return err
+	default:
+		c.cfg.WarningHandler(Warning{
+			Message: fmt.Sprintf("%s; only the first %d are included", err, c.cfg.MaxIssues),
+		})
+
+		return nil
+	}
+}
+
 func (c *Client) getIssue(ctx context.Context, key string) (Issue, error) {
-	raw, _, err := c.c.Issue.Get(ctx, key, &jira.GetQueryOptions{})
+	q := url.Values{}
+	q.Set("fields", strings.Join(c.issueFields(), ","))
+
+	if expand := c.expand(); expand != "" {
+		q.Set("expand", expand)
+	}
+
+	u := url.URL{
+		Path:     "rest/api/2/issue/" + url.PathEscape(key),
+		RawQuery: q.Encode(),
+	}
+
+	req, err := c.c.NewRequest(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return Issue{}, fmt.Errorf("getting issue: %w", err)
+		return Issue{}, fmt.Errorf("creating issue request: %w", err)
+	}
+
+	var raw rawIssue
+
+	resp, err := c.c.Do(req, &raw)
+	if err != nil {
+		return Issue{}, fmt.Errorf("getting issue %q: %w", key, jira.NewJiraError(resp, err))
+	}
+
+	return c.issueFromRaw(raw), nil
+}
+
+func (c *Client) issueFromRaw(raw rawIssue) Issue {
+	issue := raw.Issue
+
+	color, err := colorFromRaw(issue, c.fieldID(FieldColor), c.cfg.Colors)
+	if err != nil {
+		c.cfg.WarningHandler(Warning{
+			IssueKey: issue.Key,
+			Field:    FieldColor,
+			Message:  err.Error(),
+		})
+	}
+
+	now := time.Now().In(c.cfg.Location)
+
+	res := Issue{
+		Key:           issue.Key,
+		Color:         color,
+		Priority:      priorityFromRaw(issue),
+		Status:        issue.Fields.Status.Name,
+		StatusComment: statusCommentFromRaw(issue),
+		Summary:       issue.Fields.Summary,
+		TargetEnd:     customStringFieldFromRaw(issue, c.fieldID(FieldTargetEnd)),
+		Updated:       time.Time(issue.Fields.Updated),
+		Fields:        c.extraFieldsFromRaw(raw),
+	}
+
+	if issue.Fields.Resolution != nil {
+		res.Resolution = issue.Fields.Resolution.Name
+		res.ResolutionDate = time.Time(issue.Fields.Resolutiondate).In(c.cfg.Location)
+	}
+
+	if c.cfg.HistoryWindow > 0 {
+		res.History = c.historyFromRaw(raw, now.Add(-c.cfg.HistoryWindow))
+	}
+
+	if res.TargetEnd != "" {
+		targetEnd, err := parseDate(res.TargetEnd, c.cfg.Location)
+		if err != nil {
+			c.cfg.WarningHandler(Warning{
+				IssueKey: issue.Key,
+				Field:    FieldTargetEnd,
+				Message:  err.Error(),
+			})
+		}
+
+		res.TargetEndDate = targetEnd
 	}
 
-	return issueFromRaw(*raw), nil
+	res.UpdateDue(now)
+
+	return res
 }
 
-func issueFromRaw(raw jira.Issue) Issue {
-	return Issue{
-		Key:           raw.Key,
-		Color:         colorFromRaw(raw),
-		Priority:      priorityFromRaw(raw),
-		Status:        raw.Fields.Status.Name,
-		StatusComment: statusCommentFromRaw(raw),
-		Summary:       raw.Fields.Summary,
-		TargetEnd:     customStringFieldFromRaw(raw, targetEndDateCustomFieldID),
+func (c *Client) extraFieldsFromRaw(raw rawIssue) map[string]FieldValue {
+	if len(c.cfg.ExtraFields) == 0 {
+		return nil
 	}
+
+	fields := make(map[string]FieldValue, len(c.cfg.ExtraFields))
+	for _, name := range c.cfg.ExtraFields {
+		fields[name] = FieldValue{Raw: raw.Fields[c.fieldIDOrName(name)]}
+	}
+
+	return fields
 }
 
 type Issue struct {
@@ -84,7 +433,29 @@ type Issue struct {
 	Status        string
 	StatusComment string
 	Summary       string
-	TargetEnd     string
+	// TargetEnd is the raw value of the target end field.
+	TargetEnd string
+	// TargetEndDate is the parsed TargetEnd or the zero
+	// time if the field is empty or invalid.
+	TargetEndDate time.Time
+	// Overdue is true if TargetEndDate lies in the past.
+	Overdue bool
+	// DueInDays is the number of days until TargetEndDate.
+	// It is negative for overdue issues.
+	DueInDays int
+	// DueThisWeek is true if TargetEndDate lies between
+	// today and the end of the current ISO week.
+	DueThisWeek bool
+	Updated     time.Time
+	// Resolution is the name of the resolution of closed issues.
+	Resolution     string
+	ResolutionDate time.Time
+	// Fields holds the values of the configured extra
+	// fields keyed by their logical name.
+	Fields map[string]FieldValue
+	// History lists changes of the status, color and target
+	// end fields within the configured history window.
+	History []HistoryEntry
 }
 
 func priorityFromRaw(raw jira.Issue) string {
@@ -97,14 +468,54 @@ func priorityFromRaw(raw jira.Issue) string {
 	return raw.Fields.Priority.Name
 }
 
-func colorFromRaw(raw jira.Issue) Color {
-	colorField := raw.Fields.Unknowns[colorCustomFieldID]
-	var c string
-	if colorFieldMap, ok := colorField.(map[string]interface{}); ok {
-		c = colorFieldMap["value"].(string)
+var (
+	ErrInvalidColor = errors.New("invalid color field")
+	ErrUnknownColor = errors.New("unknown color")
+)
+
+// colorFromRaw decodes the color custom field of the given issue.
+// A missing or empty field results in ColorNone without error.
+func colorFromRaw(raw jira.Issue, customFieldID string, scale ColorScale) (Color, error) {
+	if raw.Fields == nil {
+		return ColorNone, nil
 	}
 
-	return ParseColor(c)
+	c, err := colorValue(raw.Fields.Unknowns[customFieldID])
+	if err != nil {
+		return ColorNone, err
+	}
+
+	color := scale.Parse(c)
+	if color == ColorNone && c != "" {
+		return ColorNone, fmt.Errorf("%w: %q is not part of the color scale", ErrUnknownColor, c)
+	}
+
+	return color, nil
+}
+
+// colorValue extracts the color name from plain string values,
+// option objects, options nested in the "value" key of other
+// options and single element arrays of any of those.
+func colorValue(field interface{}) (string, error) {
+	switch f := field.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return f, nil
+	case map[string]interface{}:
+		return colorValue(f["value"])
+	case []interface{}:
+		switch len(f) {
+		case 0:
+			return "", nil
+		case 1:
+			return colorValue(f[0])
+		default:
+			return "", fmt.Errorf("%w: expected a single value, got %d", ErrInvalidColor, len(f))
+		}
+	default:
+		return "", fmt.Errorf("%w: unexpected value of type %T", ErrInvalidColor, field)
+	}
 }
 
 func customStringFieldFromRaw(raw jira.Issue, customFieldID string) string {
@@ -132,45 +543,97 @@ func statusCommentFromRaw(issue jira.Issue) string {
 	return strings.ReplaceAll(latestReportComment, "\r", "")
 }
 
-func ParseColor(raw string) Color {
-	switch strings.ToLower(raw) {
-	case "red":
-		return ColorRed
-	case "yellow":
-		return ColorYellow
-	case "green":
-		return ColorGreen
-	default:
-		return ColorNone
-	}
+type ClientConfig struct {
+	BaseURL string
+	// PageSize is the number of issues requested per search page.
+	PageSize int
+	// MaxIssues caps the number of issues fetched per search.
+	// Zero means unlimited.
+	MaxIssues int
+	// MaxIssuesPolicy decides what happens when a search
+	// matches more than MaxIssues issues.
+	MaxIssuesPolicy MaxIssuesPolicy
+	// Workers is the maximum number of issues fetched concurrently.
+	Workers int
+	// RequestsPerSecond limits the rate of requests sent to JIRA.
+	// Zero or less disables rate limiting.
+	RequestsPerSecond float64
+	// RequestBurst is the number of requests which may
+	// exceed RequestsPerSecond momentarily.
+	RequestBurst int
+	// Retry enables retries of transient request failures.
+	Retry *RetryConfig
+	// Fields maps logical field names to JIRA fields.
+	// Entries missing from the defaults are added.
+	Fields map[string]FieldRef
+	// Location is the time zone used to interpret dates
+	// and to count days until they are due.
+	Location *time.Location
+	// ExtraFields lists additional fields fetched for every issue.
+	// Entries are looked up in Fields and fall back to being
+	// used as JIRA field IDs, e.g. "assignee" or "fixVersions".
+	ExtraFields []string
+	// HistoryWindow enables fetching issue changelogs and
+	// selects how far back changes are included in Issue.History.
+	HistoryWindow time.Duration
+	// WarningHandler receives non-fatal problems encountered
+	// while talking to JIRA.
+	WarningHandler WarningHandler
+	// DryRun receives requests which would modify JIRA
+	// instead of sending them, if set.
+	DryRun io.Writer
+	// Metrics registers request metrics if set.
+	Metrics prometheus.Registerer
+	// RecordDir stores all responses received from JIRA, if set.
+	RecordDir string
+	// ReplayDir serves responses stored via RecordDir
+	// instead of sending requests to JIRA, if set.
+	ReplayDir string
+	// Colors is the scale values of the color field are parsed with.
+	Colors ColorScale
 }
 
-type Color string
+func (c *ClientConfig) Default() {
+	if c.PageSize <= 0 {
+		c.PageSize = defaultPageSize
+	}
 
-func (c Color) String() string {
-	return string(c)
-}
+	if c.BaseURL == "" && c.ReplayDir != "" {
+		c.BaseURL = replayBaseURL
+	}
 
-func (c Color) Less(other Color) bool {
-	return _colorOrd[c] < _colorOrd[other]
-}
+	fields := DefaultFieldMapping()
+	for name, ref := range c.Fields {
+		fields[name] = ref
+	}
 
-const (
-	ColorNone   Color = ""
-	ColorRed    Color = "Red"
-	ColorYellow Color = "Yellow"
-	ColorGreen  Color = "Green"
-)
+	c.Fields = fields
 
-var _colorOrd = map[Color]int{
-	ColorNone:   0,
-	ColorRed:    1,
-	ColorYellow: 2,
-	ColorGreen:  3,
-}
+	if c.Location == nil {
+		c.Location = time.Local
+	}
 
-type ClientConfig struct {
-	BaseURL string
+	if c.Workers <= 0 {
+		c.Workers = defaultWorkers
+	}
+
+	if c.RequestBurst <= 0 {
+		c.RequestBurst = 1
+	}
+
+	if c.MaxIssuesPolicy == "" {
+		c.MaxIssuesPolicy = MaxIssuesPolicyWarn
+	}
+
+	if len(c.Colors) == 0 {
+		c.Colors = DefaultColorScale()
+	}
+
+	if c.WarningHandler == nil {
+		c.WarningHandler = func(w Warning) {
+			log.Printf("warning: %s", w)
+		}
+	}
 }
 
 func (c *ClientConfig) Option(opts ...ClientOption) {
@@ -182,3 +645,47 @@ func (c *ClientConfig) Option(opts ...ClientOption) {
 type ClientOption interface {
 	ConfigureClient(*ClientConfig)
 }
+
+type MaxIssuesPolicy string
+
+const (
+	// MaxIssuesPolicyWarn truncates the results and emits a warning.
+	MaxIssuesPolicyWarn MaxIssuesPolicy = "warn"
+	// MaxIssuesPolicyFail aborts the search with ErrTooManyIssues.
+	MaxIssuesPolicyFail MaxIssuesPolicy = "fail"
+)
+
+var ErrUnknownMaxIssuesPolicy = errors.New("unknown max issues policy")
+
+func ParseMaxIssuesPolicy(raw string) (MaxIssuesPolicy, error) {
+	switch p := MaxIssuesPolicy(strings.ToLower(raw)); p {
+	case MaxIssuesPolicyWarn, MaxIssuesPolicyFail:
+		return p, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownMaxIssuesPolicy, raw)
+	}
+}
+
+// Warning describes a non-fatal problem encountered by the client.
+type Warning struct {
+	// IssueKey is the key of the affected issue, if any.
+	IssueKey string
+	// Field is the logical name of the affected field, if any.
+	Field   string
+	Message string
+}
+
+func (w Warning) String() string {
+	msg := w.Message
+	if w.Field != "" {
+		msg = fmt.Sprintf("field %q: %s", w.Field, msg)
+	}
+
+	if w.IssueKey == "" {
+		return msg
+	}
+
+	return fmt.Sprintf("[%s] %s", w.IssueKey, msg)
+}
+
+type WarningHandler func(Warning)
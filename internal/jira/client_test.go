@@ -0,0 +1,514 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+)
+
+func TestClient_SearchIssues_Pagination(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Total         int
+		Options       []ClientOption
+		ExpectedCount int
+		ExpectedErr   error
+		ExpectWarning bool
+	}{
+		"single page": {
+			Total:         3,
+			Options:       []ClientOption{WithPageSize(5)},
+			ExpectedCount: 3,
+		},
+		"multiple pages": {
+			Total:         12,
+			Options:       []ClientOption{WithPageSize(5)},
+			ExpectedCount: 12,
+		},
+		"cap exceeded with warning": {
+			Total:         12,
+			Options:       []ClientOption{WithPageSize(5), WithMaxIssues(7)},
+			ExpectedCount: 7,
+			ExpectWarning: true,
+		},
+		"cap reached on last page": {
+			Total:         10,
+			Options:       []ClientOption{WithPageSize(5), WithMaxIssues(7)},
+			ExpectedCount: 7,
+			ExpectWarning: true,
+		},
+		"cap exceeded with failure": {
+			Total: 12,
+			Options: []ClientOption{
+				WithPageSize(5),
+				WithMaxIssues(7),
+				WithMaxIssuesPolicy(MaxIssuesPolicyFail),
+			},
+			ExpectedErr: ErrTooManyIssues,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(&fakeJIRA{Total: tc.Total})
+			defer srv.Close()
+
+			var warnings []Warning
+
+			opts := append([]ClientOption{
+				WithBaseURL(srv.URL),
+				WithWarningHandler(func(w Warning) { warnings = append(warnings, w) }),
+			}, tc.Options...)
+
+			c, err := NewClient(srv.Client(), opts...)
+			require.NoError(t, err)
+
+			issues, err := c.SearchIssues(context.Background(), "project = TEST")
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, issues, tc.ExpectedCount)
+			for i, issue := range issues {
+				assert.Equal(t, fakeIssueKey(i), issue.Key)
+			}
+
+			if tc.ExpectWarning {
+				assert.Len(t, warnings, 1)
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func fakeIssueKey(i int) string {
+	return fmt.Sprintf("TEST-%d", i+1)
+}
+
+func TestClient_SearchIssues_NoPerIssueRequests(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeJIRA{
+		Total:         4,
+		TruncatedKeys: map[string]bool{"TEST-3": true},
+		Comments:      true,
+	}
+
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	c, err := NewClient(srv.Client(), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	issues, err := c.SearchIssues(context.Background(), "project = TEST")
+	require.NoError(t, err)
+	require.Len(t, issues, 4)
+
+	assert.Equal(t, int32(1), fake.issueRequests.Load(), "only truncated issues are fetched individually")
+	assert.Equal(t, "latest TEST-3", issues[2].StatusComment)
+	assert.Equal(t, "search TEST-1", issues[0].StatusComment)
+	assert.Contains(t, fake.lastFields.Load(), "comment")
+}
+
+func TestClient_CountIssues(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(&fakeJIRA{Total: 42})
+	defer srv.Close()
+
+	c, err := NewClient(srv.Client(), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	total, err := c.CountIssues(context.Background(), "project = TEST")
+	require.NoError(t, err)
+	assert.Equal(t, 42, total)
+}
+
+func TestClient_SearchIssues_ConcurrentFetch(t *testing.T) {
+	t.Parallel()
+
+	truncated := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		truncated[fakeIssueKey(i)] = true
+	}
+
+	for name, tc := range map[string]struct {
+		FailingKeys map[string]bool
+		ExpectErr   bool
+	}{
+		"keeps search order": {},
+		"failure aborts search": {
+			FailingKeys: map[string]bool{"TEST-5": true},
+			ExpectErr:   true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &fakeJIRA{
+				Total:         20,
+				TruncatedKeys: truncated,
+				FailingKeys:   tc.FailingKeys,
+				Comments:      true,
+			}
+
+			srv := httptest.NewServer(fake)
+			defer srv.Close()
+
+			c, err := NewClient(
+				srv.Client(),
+				WithBaseURL(srv.URL),
+				WithWorkers(5),
+				WithRequestsPerSecond(1000),
+				WithRequestBurst(5),
+			)
+			require.NoError(t, err)
+
+			issues, err := c.SearchIssues(context.Background(), "project = TEST")
+			if tc.ExpectErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, issues, 20)
+			for i, issue := range issues {
+				assert.Equal(t, fakeIssueKey(i), issue.Key)
+				assert.Equal(t, "latest "+fakeIssueKey(i), issue.StatusComment)
+			}
+		})
+	}
+}
+
+func TestClient_SearchIssues_FailureCancelsFetches(t *testing.T) {
+	t.Parallel()
+
+	truncated := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		truncated[fakeIssueKey(i)] = true
+	}
+
+	fake := &fakeJIRA{Total: 20, TruncatedKeys: truncated, Comments: true}
+
+	var (
+		waiting   = make(chan struct{}, 20)
+		cancelled atomic.Int32
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/rest/api/2/issue/") {
+			fake.ServeHTTP(w, r)
+
+			return
+		}
+
+		fake.issueRequests.Add(1)
+
+		// The fifth worker fails once the other four wait for their response.
+		if strings.HasSuffix(r.URL.Path, "/"+fakeIssueKey(4)) {
+			for i := 0; i < 4; i++ {
+				select {
+				case <-waiting:
+				case <-time.After(10 * time.Second):
+				}
+			}
+
+			http.Error(w, "boom", http.StatusInternalServerError)
+
+			return
+		}
+
+		waiting <- struct{}{}
+
+		select {
+		case <-r.Context().Done():
+			cancelled.Add(1)
+		case <-time.After(10 * time.Second):
+			writeJSON(w, fake.issue(strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/"), "late", false))
+		}
+	}))
+
+	c, err := NewClient(srv.Client(), WithBaseURL(srv.URL), WithWorkers(5))
+	require.NoError(t, err)
+
+	_, err = c.SearchIssues(context.Background(), "project = TEST")
+	require.Error(t, err)
+
+	// Close waits for all handlers to return.
+	srv.Close()
+
+	assert.Equal(t, int32(4), cancelled.Load(), "in-flight fetches are cancelled")
+	assert.Equal(t, int32(5), fake.issueRequests.Load(), "remaining fetches are not sent")
+}
+
+func TestClient_SearchIssues_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	const interval = 50 * time.Millisecond
+
+	truncated := map[string]bool{}
+	for i := 0; i < 4; i++ {
+		truncated[fakeIssueKey(i)] = true
+	}
+
+	fake := &fakeJIRA{Total: 4, TruncatedKeys: truncated, Comments: true}
+
+	var (
+		lock  sync.Mutex
+		times []time.Time
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		times = append(times, time.Now())
+		lock.Unlock()
+
+		fake.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(
+		srv.Client(),
+		WithBaseURL(srv.URL),
+		WithWorkers(4),
+		WithRequestsPerSecond(float64(time.Second/interval)),
+		WithRequestBurst(1),
+	)
+	require.NoError(t, err)
+
+	_, err = c.SearchIssues(context.Background(), "project = TEST")
+	require.NoError(t, err)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// One search and four concurrent issue fetches.
+	require.GreaterOrEqual(t, len(times), 5)
+
+	slices.SortFunc(times, func(a, b time.Time) bool { return a.Before(b) })
+
+	for i := 1; i < len(times); i++ {
+		assert.GreaterOrEqual(t, times[i].Sub(times[i-1]), interval/2, "request %d", i)
+	}
+}
+
+func TestClient_SearchIssues_ExtraFields(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeJIRA{
+		Total: 1,
+		IssueFields: map[string]interface{}{
+			"assignee":          map[string]interface{}{"displayName": "Jane Doe"},
+			"customfield_10002": 3.0,
+		},
+	}
+
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	c, err := NewClient(
+		srv.Client(),
+		WithBaseURL(srv.URL),
+		WithFields{"storyPoints": {ID: "customfield_10002"}},
+		WithExtraFields{"assignee", "storyPoints", "fixVersions"},
+	)
+	require.NoError(t, err)
+
+	issues, err := c.SearchIssues(context.Background(), "project = TEST")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+
+	assert.Contains(t, fake.lastFields.Load(), "customfield_10002")
+	assert.Equal(t, "Jane Doe", issues[0].Fields["assignee"].String())
+	assert.Equal(t, "3", issues[0].Fields["storyPoints"].String())
+	assert.True(t, issues[0].Fields["fixVersions"].IsEmpty())
+}
+
+func TestColorFromRaw(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Fixture     string
+		Expected    Color
+		ExpectedErr error
+	}{
+		"option":        {Fixture: "option.json", Expected: ColorGreen},
+		"missing":       {Fixture: "missing.json", Expected: ColorNone},
+		"null":          {Fixture: "null.json", Expected: ColorNone},
+		"null value":    {Fixture: "null-value.json", Expected: ColorNone},
+		"string":        {Fixture: "string.json", Expected: ColorRed},
+		"nested option": {Fixture: "nested-option.json", Expected: ColorYellow},
+		"option array":  {Fixture: "option-array.json", Expected: ColorRed},
+		"numeric value": {Fixture: "numeric-value.json", ExpectedErr: ErrInvalidColor},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := loadRawIssueFixture(t, filepath.Join("color", tc.Fixture))
+
+			color, err := colorFromRaw(raw.Issue, DefaultFieldMapping()[FieldColor].ID, DefaultColorScale())
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.Expected, color)
+		})
+	}
+}
+
+func TestClient_issueFromRaw_InvalidColorWarns(t *testing.T) {
+	t.Parallel()
+
+	var warnings []Warning
+
+	c, err := NewClient(nil, WithWarningHandler(func(w Warning) { warnings = append(warnings, w) }))
+	require.NoError(t, err)
+	require.NoError(t, c.ResolveFields(context.Background()))
+
+	issue := c.issueFromRaw(loadRawIssueFixture(t, filepath.Join("color", "numeric-value.json")))
+
+	assert.Equal(t, ColorNone, issue.Color)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "SDE-1241", warnings[0].IssueKey)
+	assert.Equal(t, FieldColor, warnings[0].Field)
+}
+
+func loadRawIssueFixture(t *testing.T, name string) rawIssue {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	var raw rawIssue
+	require.NoError(t, json.Unmarshal(data, &raw))
+
+	return raw
+}
+
+// fakeJIRA serves a minimal subset of the JIRA REST API
+// backed by Total generated issues.
+type fakeJIRA struct {
+	Total int
+	// TruncatedKeys lists issues whose comments are
+	// only partially returned by searches.
+	TruncatedKeys map[string]bool
+	// FailingKeys lists issues which cannot be fetched individually.
+	FailingKeys map[string]bool
+	Comments    bool
+	// Fields is served by the field listing endpoint.
+	Fields []map[string]interface{}
+	// IssueFields are added to the fields of every issue.
+	IssueFields map[string]interface{}
+
+	issueRequests atomic.Int32
+	lastFields    atomic.Value
+}
+
+func (f *fakeJIRA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/rest/api/2/search":
+		f.search(w, r)
+	case r.URL.Path == "/rest/api/2/field":
+		writeJSON(w, f.Fields)
+	case strings.HasPrefix(r.URL.Path, "/rest/api/2/issue/"):
+		f.issueRequests.Add(1)
+
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+		if f.FailingKeys[key] {
+			http.Error(w, "boom", http.StatusInternalServerError)
+
+			return
+		}
+
+		writeJSON(w, f.issue(key, "latest "+key, false))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeJIRA) search(w http.ResponseWriter, r *http.Request) {
+	f.lastFields.Store(r.URL.Query().Get("fields"))
+
+	startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+	maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+	if maxResults == 0 {
+		maxResults = 50
+	}
+
+	issues := []map[string]interface{}{}
+	for i := startAt; i < f.Total && i < startAt+maxResults; i++ {
+		key := fakeIssueKey(i)
+		issues = append(issues, f.issue(key, "search "+key, f.TruncatedKeys[key]))
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      f.Total,
+		"issues":     issues,
+	})
+}
+
+func (f *fakeJIRA) issue(key, comment string, truncated bool) map[string]interface{} {
+	fields := map[string]interface{}{
+		"summary": "Summary of " + key,
+		"status":  map[string]interface{}{"name": "In Progress"},
+	}
+
+	for k, v := range f.IssueFields {
+		fields[k] = v
+	}
+
+	if f.Comments {
+		total := 1
+		if truncated {
+			total = 2
+		}
+
+		fields["comment"] = map[string]interface{}{
+			"total": total,
+			"comments": []map[string]interface{}{
+				{"body": reportCommentPrefix + " " + comment},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"key":    key,
+		"fields": fields,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,142 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Color string
+
+func (c Color) String() string {
+	return string(c)
+}
+
+// Less orders colors by their position in the default color scale.
+// Use ColorScale.Less to order colors of a configured scale.
+func (c Color) Less(other Color) bool {
+	return DefaultColorScale().Less(c, other)
+}
+
+const (
+	ColorNone   Color = ""
+	ColorRed    Color = "Red"
+	ColorYellow Color = "Yellow"
+	ColorGreen  Color = "Green"
+)
+
+// ParseColor returns the color of the default color scale
+// matching raw case-insensitively or ColorNone.
+// Use ColorScale.Parse to parse values of a configured scale.
+func ParseColor(raw string) Color {
+	return DefaultColorScale().Parse(raw)
+}
+
+// ColorDefinition describes a single value of a color scale.
+type ColorDefinition struct {
+	// Value is the value of the color field in JIRA.
+	// An empty value describes issues without a color.
+	Value string `json:"value"`
+	// Label is displayed instead of Value if set.
+	Label string `json:"label,omitempty"`
+	// Emoji is an optional emoji representing the color.
+	Emoji string `json:"emoji,omitempty"`
+	// Markup is optional markup templates may use to render the color.
+	Markup string `json:"markup,omitempty"`
+}
+
+// ColorScale lists the known colors in their sort order.
+// Issues without a color sort first unless the scale
+// contains an entry with an empty value.
+type ColorScale []ColorDefinition
+
+var ErrDuplicateColor = errors.New("duplicate color")
+
+func (s ColorScale) Validate() error {
+	seen := make(map[string]struct{}, len(s))
+
+	for _, def := range s {
+		key := strings.ToLower(def.Value)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateColor, def.Value)
+		}
+
+		seen[key] = struct{}{}
+	}
+
+	return nil
+}
+
+// Parse returns the color of the scale matching
+// raw case-insensitively or ColorNone.
+func (s ColorScale) Parse(raw string) Color {
+	if raw == "" {
+		return ColorNone
+	}
+
+	for _, def := range s {
+		if strings.EqualFold(def.Value, raw) {
+			return Color(def.Value)
+		}
+	}
+
+	return ColorNone
+}
+
+// Less reports whether a precedes b in the scale.
+func (s ColorScale) Less(a, b Color) bool {
+	return s.ord(a) < s.ord(b)
+}
+
+// Label returns the display label of c in the scale.
+func (s ColorScale) Label(c Color) string {
+	if def, ok := s.lookup(c); ok && def.Label != "" {
+		return def.Label
+	}
+
+	return string(c)
+}
+
+// Emoji returns the emoji configured for c, if any.
+func (s ColorScale) Emoji(c Color) string {
+	def, _ := s.lookup(c)
+
+	return def.Emoji
+}
+
+// Markup returns the markup configured for c, if any.
+func (s ColorScale) Markup(c Color) string {
+	def, _ := s.lookup(c)
+
+	return def.Markup
+}
+
+func (s ColorScale) lookup(c Color) (ColorDefinition, bool) {
+	for _, def := range s {
+		if def.Value == string(c) {
+			return def, true
+		}
+	}
+
+	return ColorDefinition{}, false
+}
+
+func (s ColorScale) ord(c Color) int {
+	for i, def := range s {
+		if def.Value == string(c) {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// DefaultColorScale returns the scale used
+// unless another one is configured.
+func DefaultColorScale() ColorScale {
+	return ColorScale{
+		{Value: string(ColorRed), Emoji: "🔴"},
+		{Value: string(ColorYellow), Emoji: "🟡"},
+		{Value: string(ColorGreen), Emoji: "🟢"},
+	}
+}
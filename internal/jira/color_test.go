@@ -0,0 +1,127 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slices"
+)
+
+func TestColorScale_Parse(t *testing.T) {
+	t.Parallel()
+
+	scale := ColorScale{
+		{Value: "Red"},
+		{Value: "Blue", Label: "Not Started"},
+	}
+
+	for name, tc := range map[string]struct {
+		Raw      string
+		Expected Color
+	}{
+		"empty":            {Raw: "", Expected: ColorNone},
+		"exact":            {Raw: "Red", Expected: ColorRed},
+		"case-insensitive": {Raw: "bLuE", Expected: Color("Blue")},
+		"unknown":          {Raw: "Green", Expected: ColorNone},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, scale.Parse(tc.Raw))
+		})
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ColorYellow, ParseColor("yellow"))
+	assert.Equal(t, ColorNone, ParseColor("Blue"))
+	assert.True(t, ColorRed.Less(ColorGreen))
+	assert.False(t, ColorGreen.Less(ColorNone))
+}
+
+func TestColorScale_Less(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Scale    ColorScale
+		Colors   []Color
+		Expected []Color
+	}{
+		"default": {
+			Scale:    DefaultColorScale(),
+			Colors:   []Color{ColorGreen, ColorNone, ColorRed, ColorYellow},
+			Expected: []Color{ColorNone, ColorRed, ColorYellow, ColorGreen},
+		},
+		"custom with positioned none": {
+			Scale: ColorScale{
+				{Value: "Red"},
+				{Value: "Yellow"},
+				{Value: "Green"},
+				{Value: "Blue"},
+				{Value: "", Label: "Unknown"},
+				{Value: "Grey"},
+			},
+			Colors:   []Color{"Grey", ColorNone, "Blue", ColorGreen, ColorRed},
+			Expected: []Color{ColorRed, ColorGreen, "Blue", ColorNone, "Grey"},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			colors := slices.Clone(tc.Colors)
+			slices.SortStableFunc(colors, tc.Scale.Less)
+
+			assert.Equal(t, tc.Expected, colors)
+		})
+	}
+}
+
+func TestColorScale_Label(t *testing.T) {
+	t.Parallel()
+
+	scale := ColorScale{
+		{Value: "Red", Emoji: "🔥"},
+		{Value: "Blue", Label: "Not Started", Emoji: "🔵", Markup: "<b>Not Started</b>"},
+		{Value: "", Label: "Unknown"},
+	}
+
+	for name, tc := range map[string]struct {
+		Color          Color
+		ExpectedLabel  string
+		ExpectedEmoji  string
+		ExpectedMarkup string
+	}{
+		"without label": {Color: ColorRed, ExpectedLabel: "Red", ExpectedEmoji: "🔥"},
+		"with label": {
+			Color:          "Blue",
+			ExpectedLabel:  "Not Started",
+			ExpectedEmoji:  "🔵",
+			ExpectedMarkup: "<b>Not Started</b>",
+		},
+		"none":    {Color: ColorNone, ExpectedLabel: "Unknown"},
+		"unknown": {Color: ColorGreen, ExpectedLabel: "Green"},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.ExpectedLabel, scale.Label(tc.Color))
+			assert.Equal(t, tc.ExpectedEmoji, scale.Emoji(tc.Color))
+			assert.Equal(t, tc.ExpectedMarkup, scale.Markup(tc.Color))
+		})
+	}
+}
+
+func TestColorScale_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DefaultColorScale().Validate())
+	assert.ErrorIs(t, ColorScale{{Value: "Red"}, {Value: "red"}}.Validate(), ErrDuplicateColor)
+}
@@ -0,0 +1,63 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidDate = errors.New("invalid date")
+
+// parseDate parses JIRA date and date-time values. Dates without
+// a time of day are interpreted as midnight in the given location.
+func parseDate(raw string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation(jiraDateLayout, raw, loc); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(jiraDateTimeLayout, raw); err == nil {
+		return t.In(loc), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.In(loc), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, raw)
+}
+
+// UpdateDue computes Overdue, DueInDays and DueThisWeek
+// from TargetEndDate relative to the given time.
+// Days are counted in the location of now.
+func (i *Issue) UpdateDue(now time.Time) {
+	i.Overdue, i.DueInDays, i.DueThisWeek = false, 0, false
+
+	if i.TargetEndDate.IsZero() {
+		return
+	}
+
+	today := startOfDay(now)
+	due := startOfDay(i.TargetEndDate.In(now.Location()))
+
+	i.DueInDays = daysBetween(today, due)
+	i.Overdue = i.DueInDays < 0
+
+	// ISO weeks start on Monday.
+	daysLeftInWeek := (7 - int(today.Weekday())) % 7
+	i.DueThisWeek = !i.Overdue && i.DueInDays <= daysLeftInWeek
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween counts calendar days and is therefore
+// unaffected by daylight saving time transitions.
+func daysBetween(from, to time.Time) int {
+	fromUTC := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toUTC := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(toUTC.Sub(fromUTC).Hours() / 24)
+}
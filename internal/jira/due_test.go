@@ -0,0 +1,101 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		Raw         string
+		Expected    time.Time
+		ExpectedErr error
+	}{
+		"date": {
+			Raw:      "2023-02-01",
+			Expected: time.Date(2023, time.February, 1, 0, 0, 0, 0, berlin),
+		},
+		"datetime": {
+			Raw:      "2023-02-01T10:00:00.000+0000",
+			Expected: time.Date(2023, time.February, 1, 11, 0, 0, 0, berlin),
+		},
+		"invalid": {
+			Raw:         "next week",
+			ExpectedErr: ErrInvalidDate,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			date, err := parseDate(tc.Raw, berlin)
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.True(t, tc.Expected.Equal(date), "expected %s, got %s", tc.Expected, date)
+		})
+	}
+}
+
+func TestIssue_UpdateDue(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday
+	now := time.Date(2023, time.February, 1, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time {
+		return time.Date(2023, time.February, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	for name, tc := range map[string]struct {
+		TargetEnd           time.Time
+		ExpectedOverdue     bool
+		ExpectedDueInDays   int
+		ExpectedDueThisWeek bool
+	}{
+		"no target end": {},
+		"overdue": {
+			TargetEnd:         time.Date(2023, time.January, 30, 0, 0, 0, 0, time.UTC),
+			ExpectedOverdue:   true,
+			ExpectedDueInDays: -2,
+		},
+		"today": {
+			TargetEnd:           day(1),
+			ExpectedDueThisWeek: true,
+		},
+		"sunday": {
+			TargetEnd:           day(5),
+			ExpectedDueInDays:   4,
+			ExpectedDueThisWeek: true,
+		},
+		"next monday": {
+			TargetEnd:         day(6),
+			ExpectedDueInDays: 5,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			issue := Issue{TargetEndDate: tc.TargetEnd}
+			issue.UpdateDue(now)
+
+			assert.Equal(t, tc.ExpectedOverdue, issue.Overdue)
+			assert.Equal(t, tc.ExpectedDueInDays, issue.DueInDays)
+			assert.Equal(t, tc.ExpectedDueThisWeek, issue.DueThisWeek)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package jira
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	jiraDateLayout     = "2006-01-02"
+	jiraDateTimeLayout = "2006-01-02T15:04:05.000-0700"
+)
+
+// FieldValue wraps the decoded JSON value of an arbitrary JIRA field
+// and renders it in a human readable form when used in templates.
+type FieldValue struct {
+	Raw interface{}
+}
+
+// IsEmpty reports whether the field has no value.
+func (v FieldValue) IsEmpty() bool {
+	switch raw := v.Raw.(type) {
+	case nil:
+		return true
+	case string:
+		return raw == ""
+	case []interface{}:
+		return len(raw) == 0
+	default:
+		return false
+	}
+}
+
+// Values returns the elements of array valued fields.
+// Single values are returned as a list of one element.
+func (v FieldValue) Values() []FieldValue {
+	switch raw := v.Raw.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		values := make([]FieldValue, 0, len(raw))
+		for _, elem := range raw {
+			values = append(values, FieldValue{Raw: elem})
+		}
+
+		return values
+	default:
+		return []FieldValue{v}
+	}
+}
+
+// String renders option fields by their value, users by their display
+// name, versions and components by their name, arrays as a comma
+// separated list and dates without their time of day.
+func (v FieldValue) String() string {
+	switch raw := v.Raw.(type) {
+	case nil:
+		return ""
+	case string:
+		return renderString(raw)
+	case float64:
+		return strconv.FormatFloat(raw, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(raw)
+	case []interface{}:
+		parts := make([]string, 0, len(raw))
+		for _, elem := range raw {
+			if s := (FieldValue{Raw: elem}).String(); s != "" {
+				parts = append(parts, s)
+			}
+		}
+
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		return renderObject(raw)
+	default:
+		return fmt.Sprint(raw)
+	}
+}
+
+func renderString(s string) string {
+	if t, err := time.Parse(jiraDateTimeLayout, s); err == nil {
+		return t.Format(jiraDateLayout)
+	}
+
+	return s
+}
+
+func renderObject(obj map[string]interface{}) string {
+	if value, ok := obj["value"]; ok {
+		s := FieldValue{Raw: value}.String()
+
+		if child, ok := obj["child"]; ok {
+			if c := (FieldValue{Raw: child}).String(); c != "" {
+				s += " - " + c
+			}
+		}
+
+		return s
+	}
+
+	for _, key := range []string{"displayName", "name", "key", "id"} {
+		if value, ok := obj[key]; ok {
+			return FieldValue{Raw: value}.String()
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,55 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldValue_String(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		JSON     string
+		Expected string
+	}{
+		"null":     {JSON: `null`, Expected: ""},
+		"string":   {JSON: `"text"`, Expected: "text"},
+		"number":   {JSON: `2.5`, Expected: "2.5"},
+		"date":     {JSON: `"2023-02-01"`, Expected: "2023-02-01"},
+		"datetime": {JSON: `"2023-02-01T10:00:00.000+0000"`, Expected: "2023-02-01"},
+		"option": {
+			JSON:     `{"self":"https://jira/option/1","value":"Red","id":"1"}`,
+			Expected: "Red",
+		},
+		"cascading option": {
+			JSON:     `{"value":"Platform","child":{"value":"Networking"}}`,
+			Expected: "Platform - Networking",
+		},
+		"user": {
+			JSON:     `{"name":"jdoe","displayName":"Jane Doe"}`,
+			Expected: "Jane Doe",
+		},
+		"components": {
+			JSON:     `[{"id":"1","name":"Addons"},{"id":"2","name":"Fleet"}]`,
+			Expected: "Addons, Fleet",
+		},
+		"labels": {
+			JSON:     `["a","b"]`,
+			Expected: "a, b",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var v FieldValue
+			require.NoError(t, json.Unmarshal([]byte(tc.JSON), &v.Raw))
+
+			assert.Equal(t, tc.Expected, v.String())
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Logical names of the custom fields the client depends on.
+const (
+	FieldColor     = "color"
+	FieldTargetEnd = "targetEnd"
+)
+
+var (
+	ErrUnknownField = errors.New("unknown field")
+	ErrInvalidField = errors.New("field requires either an 'id' or a 'name'")
+)
+
+// DefaultFieldMapping returns the custom field IDs used
+// when no mapping is configured for a logical field.
+func DefaultFieldMapping() map[string]FieldRef {
+	return map[string]FieldRef{
+		FieldColor:     {ID: "customfield_12320845"},
+		FieldTargetEnd: {ID: "customfield_12313942"},
+	}
+}
+
+// FieldRef identifies a JIRA field either directly by its ID
+// or by its display name, which is resolved to an ID through
+// the JIRA API. If both are set, ID takes precedence.
+type FieldRef struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// UnmarshalJSON additionally accepts a plain string
+// which is interpreted as the field ID.
+func (r *FieldRef) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*r = FieldRef{ID: id}
+
+		return nil
+	}
+
+	type fieldRef FieldRef
+
+	var ref fieldRef
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+
+	*r = FieldRef(ref)
+
+	return nil
+}
+
+func (r FieldRef) Validate() error {
+	if r.ID == "" && r.Name == "" {
+		return ErrInvalidField
+	}
+
+	return nil
+}
+
+// ResolveFields looks up the IDs of all fields which are only
+// referenced by name. It is invoked implicitly by the first
+// search, but may be called earlier to surface errors at startup.
+func (c *Client) ResolveFields(ctx context.Context) error {
+	c.fieldsLock.Lock()
+	defer c.fieldsLock.Unlock()
+
+	if c.fieldIDs != nil {
+		return nil
+	}
+
+	ids := make(map[string]string, len(c.cfg.Fields))
+	names := make(map[string]string, len(c.cfg.Fields))
+
+	var unresolved []string
+
+	for name, ref := range c.cfg.Fields {
+		names[name] = ref.Name
+
+		if ref.ID != "" {
+			ids[name] = ref.ID
+
+			continue
+		}
+
+		unresolved = append(unresolved, name)
+	}
+
+	// Changelogs reference custom fields by display name,
+	// so they have to be known even for fields mapped by ID.
+	if len(unresolved) > 0 || c.cfg.HistoryWindow > 0 {
+		fields, _, err := c.c.Field.GetList(ctx)
+		if err != nil {
+			return fmt.Errorf("listing JIRA fields: %w", err)
+		}
+
+		byName := make(map[string]string, len(fields))
+		byID := make(map[string]string, len(fields))
+
+		for _, f := range fields {
+			byName[strings.ToLower(f.Name)] = f.ID
+			byID[f.ID] = f.Name
+		}
+
+		for _, name := range unresolved {
+			displayName := c.cfg.Fields[name].Name
+
+			id, ok := byName[strings.ToLower(displayName)]
+			if !ok {
+				return fmt.Errorf("resolving field %q: %w: %q", name, ErrUnknownField, displayName)
+			}
+
+			ids[name] = id
+		}
+
+		for name, id := range ids {
+			if names[name] == "" {
+				names[name] = byID[id]
+			}
+		}
+	}
+
+	c.fieldIDs = ids
+	c.fieldNames = names
+
+	return nil
+}
+
+// fieldID returns the ID of the field with the given
+// logical name or an empty string if it is not mapped.
+func (c *Client) fieldID(name string) string {
+	c.fieldsLock.Lock()
+	defer c.fieldsLock.Unlock()
+
+	return c.fieldIDs[name]
+}
+
+// fieldIDOrName returns the ID of the field with the given logical
+// name or the name itself, assuming that it is already a field ID.
+func (c *Client) fieldIDOrName(name string) string {
+	if id := c.fieldID(name); id != "" {
+		return id
+	}
+
+	return name
+}
+
+// Field describes a field known to the JIRA server.
+type Field struct {
+	ID     string
+	Name   string
+	Custom bool
+	// Type is the JSON type of the field value, e.g.
+	// "string", "date", "option" or "array".
+	Type string
+}
+
+// ListFields returns all fields known to the JIRA server sorted by name.
+func (c *Client) ListFields(ctx context.Context) ([]Field, error) {
+	fields, _, err := c.c.Field.GetList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing JIRA fields: %w", err)
+	}
+
+	res := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		res = append(res, Field{
+			ID:     f.ID,
+			Name:   f.Name,
+			Custom: f.Custom,
+			Type:   f.Schema.Type,
+		})
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return strings.ToLower(res[i].Name) < strings.ToLower(res[j].Name)
+	})
+
+	return res, nil
+}
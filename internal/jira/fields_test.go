@@ -0,0 +1,134 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldRef_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Input    string
+		Expected FieldRef
+	}{
+		"plain id": {
+			Input:    `"customfield_1"`,
+			Expected: FieldRef{ID: "customfield_1"},
+		},
+		"by id": {
+			Input:    `{"id":"customfield_1"}`,
+			Expected: FieldRef{ID: "customfield_1"},
+		},
+		"by name": {
+			Input:    `{"name":"Story Points"}`,
+			Expected: FieldRef{Name: "Story Points"},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var ref FieldRef
+			require.NoError(t, json.Unmarshal([]byte(tc.Input), &ref))
+
+			assert.Equal(t, tc.Expected, ref)
+		})
+	}
+}
+
+func TestClient_ResolveFields(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeJIRA{
+		Fields: []map[string]interface{}{
+			{"id": "customfield_1", "name": "Health Color"},
+			{"id": "customfield_2", "name": "Story Points"},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Fields      map[string]FieldRef
+		Expected    map[string]string
+		ExpectedErr error
+	}{
+		"defaults": {
+			Expected: map[string]string{
+				FieldColor:     "customfield_12320845",
+				FieldTargetEnd: "customfield_12313942",
+			},
+		},
+		"by name": {
+			Fields: map[string]FieldRef{
+				FieldColor:    {Name: "health color"},
+				"storyPoints": {Name: "Story Points"},
+			},
+			Expected: map[string]string{
+				FieldColor:     "customfield_1",
+				FieldTargetEnd: "customfield_12313942",
+				"storyPoints":  "customfield_2",
+			},
+		},
+		"unknown name": {
+			Fields: map[string]FieldRef{
+				FieldColor: {Name: "Colour"},
+			},
+			ExpectedErr: ErrUnknownField,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(fake)
+			defer srv.Close()
+
+			c, err := NewClient(srv.Client(), WithBaseURL(srv.URL), WithFields(tc.Fields))
+			require.NoError(t, err)
+
+			err = c.ResolveFields(context.Background())
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+
+			for name, id := range tc.Expected {
+				assert.Equal(t, id, c.fieldID(name))
+			}
+		})
+	}
+}
+
+func TestClient_ListFields(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(&fakeJIRA{
+		Fields: []map[string]interface{}{
+			{"id": "customfield_2", "name": "Story Points", "custom": true, "schema": map[string]interface{}{"type": "number"}},
+			{"id": "summary", "name": "Summary", "schema": map[string]interface{}{"type": "string"}},
+			{"id": "customfield_1", "name": "health Color", "custom": true, "schema": map[string]interface{}{"type": "option"}},
+		},
+	})
+	defer srv.Close()
+
+	c, err := NewClient(srv.Client(), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	fields, err := c.ListFields(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []Field{
+		{ID: "customfield_1", Name: "health Color", Custom: true, Type: "option"},
+		{ID: "customfield_2", Name: "Story Points", Custom: true, Type: "number"},
+		{ID: "summary", Name: "Summary", Type: "string"},
+	}, fields)
+}
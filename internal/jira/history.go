@@ -0,0 +1,152 @@
+package jira
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// FieldStatus is the logical name of the status field in history entries.
+const FieldStatus = "status"
+
+// HistoryEntry is a single change of a tracked field
+// taken from the changelog of an issue.
+type HistoryEntry struct {
+	// Field is the logical name of the changed field,
+	// i.e. "status", "color" or "targetEnd".
+	Field string
+	At    time.Time
+	// Author is the display name of the user who made the change.
+	Author string
+	From   string
+	To     string
+}
+
+// ColorChanges returns the history entries of the color field.
+func (i Issue) ColorChanges() []HistoryEntry {
+	return i.historyOf(FieldColor)
+}
+
+// StatusChanges returns the history entries of the status field.
+func (i Issue) StatusChanges() []HistoryEntry {
+	return i.historyOf(FieldStatus)
+}
+
+// TargetEndChanges returns the history entries of the target end field.
+func (i Issue) TargetEndChanges() []HistoryEntry {
+	return i.historyOf(FieldTargetEnd)
+}
+
+func (i Issue) historyOf(field string) []HistoryEntry {
+	var entries []HistoryEntry
+
+	for _, e := range i.History {
+		if e.Field == field {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries
+}
+
+type rawChangelog struct {
+	Histories []rawChangelogHistory `json:"histories"`
+}
+
+type rawChangelogHistory struct {
+	Author struct {
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+	} `json:"author"`
+	Created string             `json:"created"`
+	Items   []rawChangelogItem `json:"items"`
+}
+
+type rawChangelogItem struct {
+	Field      string `json:"field"`
+	FieldID    string `json:"fieldId"`
+	FromString string `json:"fromString"`
+	ToString   string `json:"toString"`
+}
+
+// historyFromRaw returns the changes of tracked fields made at
+// or after since in chronological order. Changelog items are
+// matched by field ID if present or by field name otherwise.
+func (c *Client) historyFromRaw(raw rawIssue, since time.Time) []HistoryEntry {
+	if raw.Changelog == nil {
+		return nil
+	}
+
+	tracked := c.trackedHistoryFields()
+
+	var entries []HistoryEntry
+
+	for _, h := range raw.Changelog.Histories {
+		at, err := time.Parse(jiraDateTimeLayout, h.Created)
+		if err != nil {
+			c.cfg.WarningHandler(Warning{
+				IssueKey: raw.Issue.Key,
+				Message:  "parsing changelog entry: " + err.Error(),
+			})
+
+			continue
+		}
+
+		if at.Before(since) {
+			continue
+		}
+
+		author := h.Author.DisplayName
+		if author == "" {
+			author = h.Author.Name
+		}
+
+		for _, item := range h.Items {
+			field, ok := tracked[strings.ToLower(item.FieldID)]
+			if !ok {
+				field, ok = tracked[strings.ToLower(item.Field)]
+			}
+
+			if !ok {
+				continue
+			}
+
+			entries = append(entries, HistoryEntry{
+				Field:  field,
+				At:     at.In(c.cfg.Location),
+				Author: author,
+				From:   item.FromString,
+				To:     item.ToString,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.Before(entries[j].At)
+	})
+
+	return entries
+}
+
+// trackedHistoryFields maps lower-cased field IDs and
+// display names to the logical names of tracked fields.
+func (c *Client) trackedHistoryFields() map[string]string {
+	c.fieldsLock.Lock()
+	defer c.fieldsLock.Unlock()
+
+	tracked := map[string]string{
+		FieldStatus: FieldStatus,
+	}
+
+	for _, name := range []string{FieldColor, FieldTargetEnd} {
+		if id := c.fieldIDs[name]; id != "" {
+			tracked[strings.ToLower(id)] = name
+		}
+
+		if displayName := c.fieldNames[name]; displayName != "" {
+			tracked[strings.ToLower(displayName)] = name
+		}
+	}
+
+	return tracked
+}
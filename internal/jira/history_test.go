@@ -0,0 +1,60 @@
+package jira
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_historyFromRaw(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewClient(nil, WithHistoryWindow(7*24*time.Hour), WithLocation{Location: time.UTC})
+	require.NoError(t, err)
+
+	// The color field is matched by ID while the
+	// target end field is matched by display name.
+	c.fieldIDs = map[string]string{
+		FieldColor:     "customfield_12320845",
+		FieldTargetEnd: "customfield_12313942",
+	}
+	c.fieldNames = map[string]string{
+		FieldTargetEnd: "Target End",
+	}
+
+	raw := loadRawIssueFixture(t, filepath.Join("changelog", "issue.json"))
+	since := time.Date(2023, time.January, 25, 0, 0, 0, 0, time.UTC)
+
+	issue := Issue{History: c.historyFromRaw(raw, since)}
+
+	assert.Equal(t, []HistoryEntry{
+		{
+			Field:  FieldColor,
+			At:     time.Date(2023, time.January, 31, 8, 0, 0, 0, time.UTC),
+			Author: "Bob Smith",
+			From:   "Green",
+			To:     "Red",
+		},
+		{
+			Field:  FieldTargetEnd,
+			At:     time.Date(2023, time.January, 31, 10, 0, 0, 0, time.UTC),
+			Author: "Jane Doe",
+			From:   "2023-02-01",
+			To:     "2023-02-08",
+		},
+		{
+			Field:  FieldStatus,
+			At:     time.Date(2023, time.February, 1, 8, 0, 0, 0, time.UTC),
+			Author: "bsmith",
+			From:   "In Progress",
+			To:     "Review",
+		},
+	}, issue.History)
+
+	assert.Len(t, issue.ColorChanges(), 1)
+	assert.Len(t, issue.TargetEndChanges(), 1)
+	assert.Len(t, issue.StatusChanges(), 1)
+}
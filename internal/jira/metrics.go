@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func newRequestMetrics(reg prometheus.Registerer) (*requestMetrics, error) {
+	m := &requestMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "jira_wrangler_jira_requests_total",
+			Help: "Number of requests sent to JIRA by endpoint, method and status code.",
+		}, []string{"endpoint", "method", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "jira_wrangler_jira_request_errors_total",
+			Help: "Number of JIRA requests failing in transport or with a status code of 400 or above.",
+		}, []string{"endpoint", "method"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "jira_wrangler_jira_request_duration_seconds",
+			Help:    "Latency of JIRA requests.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint", "method"}),
+	}
+
+	for _, c := range []prometheus.Collector{m.requests, m.errors, m.duration} {
+		if err := reg.Register(c); err != nil {
+			return nil, fmt.Errorf("registering JIRA metrics: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+type requestMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// instrumentedTransport records every request attempt, so
+// retries are counted individually and time spent waiting
+// for the rate limiter is excluded from the latency.
+type instrumentedTransport struct {
+	metrics *requestMetrics
+	next    http.RoundTripper
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointOf(req.URL.Path)
+	start := time.Now()
+
+	res, err := t.next.RoundTrip(req)
+
+	t.metrics.duration.WithLabelValues(endpoint, req.Method).Observe(time.Since(start).Seconds())
+
+	code := ""
+	if res != nil {
+		code = strconv.Itoa(res.StatusCode)
+	}
+
+	t.metrics.requests.WithLabelValues(endpoint, req.Method, code).Inc()
+
+	if err != nil || res.StatusCode >= http.StatusBadRequest {
+		t.metrics.errors.WithLabelValues(endpoint, req.Method).Inc()
+	}
+
+	return res, err
+}
+
+// endpointOf strips the context path of the JIRA server
+// and replaces issue keys and IDs with "{key}" to keep the
+// number of label values bounded, e.g.
+// "/jira/rest/api/2/issue/SDE-1/comment" becomes
+// "rest/api/2/issue/{key}/comment".
+func endpointOf(path string) string {
+	if i := strings.Index(path, "rest/"); i >= 0 {
+		path = path[i:]
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] == "issue" {
+			segments[i] = "{key}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointOf(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Path     string
+		Expected string
+	}{
+		"search": {
+			Path:     "/rest/api/2/search",
+			Expected: "rest/api/2/search",
+		},
+		"issue": {
+			Path:     "/rest/api/2/issue/SDE-1",
+			Expected: "rest/api/2/issue/{key}",
+		},
+		"comment": {
+			Path:     "/rest/api/2/issue/SDE-1/comment",
+			Expected: "rest/api/2/issue/{key}/comment",
+		},
+		"create issue": {
+			Path:     "/rest/api/2/issue",
+			Expected: "rest/api/2/issue",
+		},
+		"context path": {
+			Path:     "/jira/rest/api/2/field",
+			Expected: "rest/api/2/field",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, endpointOf(tc.Path))
+		})
+	}
+}
+
+func TestClient_Metrics(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeJIRA{
+		Total:         4,
+		TruncatedKeys: map[string]bool{"TEST-3": true},
+		FailingKeys:   map[string]bool{"FAILING-1": true},
+		Comments:      true,
+	}
+
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	reg := prometheus.NewRegistry()
+
+	c, err := NewClient(srv.Client(), WithBaseURL(srv.URL), WithMetrics{Registerer: reg})
+	require.NoError(t, err)
+
+	_, err = c.SearchIssues(context.Background(), "project = TEST")
+	require.NoError(t, err)
+
+	_, err = c.getIssue(context.Background(), "FAILING-1")
+	require.Error(t, err)
+
+	expected := `
+# HELP jira_wrangler_jira_requests_total Number of requests sent to JIRA by endpoint, method and status code.
+# TYPE jira_wrangler_jira_requests_total counter
+jira_wrangler_jira_requests_total{code="200",endpoint="rest/api/2/issue/{key}",method="GET"} 1
+jira_wrangler_jira_requests_total{code="200",endpoint="rest/api/2/search",method="GET"} 1
+jira_wrangler_jira_requests_total{code="500",endpoint="rest/api/2/issue/{key}",method="GET"} 1
+# HELP jira_wrangler_jira_request_errors_total Number of JIRA requests failing in transport or with a status code of 400 or above.
+# TYPE jira_wrangler_jira_request_errors_total counter
+jira_wrangler_jira_request_errors_total{endpoint="rest/api/2/issue/{key}",method="GET"} 1
+`
+
+	assert.NoError(t, testutil.GatherAndCompare(
+		reg, strings.NewReader(expected),
+		"jira_wrangler_jira_requests_total", "jira_wrangler_jira_request_errors_total",
+	))
+	assert.Equal(t, 2, testutil.CollectAndCount(reg, "jira_wrangler_jira_request_duration_seconds"))
+}
@@ -1,7 +1,124 @@
 package jira
 
+import (
+	"io"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
 type WithBaseURL string
 
 func (w WithBaseURL) ConfigureClient(c *ClientConfig) {
 	c.BaseURL = string(w)
 }
+
+type WithPageSize int
+
+func (w WithPageSize) ConfigureClient(c *ClientConfig) {
+	c.PageSize = int(w)
+}
+
+type WithMaxIssues int
+
+func (w WithMaxIssues) ConfigureClient(c *ClientConfig) {
+	c.MaxIssues = int(w)
+}
+
+type WithMaxIssuesPolicy MaxIssuesPolicy
+
+func (w WithMaxIssuesPolicy) ConfigureClient(c *ClientConfig) {
+	c.MaxIssuesPolicy = MaxIssuesPolicy(w)
+}
+
+type WithWorkers int
+
+func (w WithWorkers) ConfigureClient(c *ClientConfig) {
+	c.Workers = int(w)
+}
+
+type WithRequestsPerSecond float64
+
+func (w WithRequestsPerSecond) ConfigureClient(c *ClientConfig) {
+	c.RequestsPerSecond = float64(w)
+}
+
+type WithRequestBurst int
+
+func (w WithRequestBurst) ConfigureClient(c *ClientConfig) {
+	c.RequestBurst = int(w)
+}
+
+type WithRetry RetryConfig
+
+func (w WithRetry) ConfigureClient(c *ClientConfig) {
+	cfg := RetryConfig(w)
+
+	c.Retry = &cfg
+}
+
+type WithFields map[string]FieldRef
+
+func (w WithFields) ConfigureClient(c *ClientConfig) {
+	c.Fields = map[string]FieldRef(w)
+}
+
+type WithExtraFields []string
+
+func (w WithExtraFields) ConfigureClient(c *ClientConfig) {
+	c.ExtraFields = []string(w)
+}
+
+type WithLocation struct {
+	*time.Location
+}
+
+func (w WithLocation) ConfigureClient(c *ClientConfig) {
+	c.Location = w.Location
+}
+
+type WithHistoryWindow time.Duration
+
+func (w WithHistoryWindow) ConfigureClient(c *ClientConfig) {
+	c.HistoryWindow = time.Duration(w)
+}
+
+type WithWarningHandler WarningHandler
+
+func (w WithWarningHandler) ConfigureClient(c *ClientConfig) {
+	c.WarningHandler = WarningHandler(w)
+}
+
+type WithDryRun struct {
+	io.Writer
+}
+
+func (w WithDryRun) ConfigureClient(c *ClientConfig) {
+	c.DryRun = w.Writer
+}
+
+type WithMetrics struct {
+	prometheus.Registerer
+}
+
+func (w WithMetrics) ConfigureClient(c *ClientConfig) {
+	c.Metrics = w.Registerer
+}
+
+type WithRecordDir string
+
+func (w WithRecordDir) ConfigureClient(c *ClientConfig) {
+	c.RecordDir = string(w)
+}
+
+type WithReplayDir string
+
+func (w WithReplayDir) ConfigureClient(c *ClientConfig) {
+	c.ReplayDir = string(w)
+}
+
+type WithColorScale ColorScale
+
+func (w WithColorScale) ConfigureClient(c *ClientConfig) {
+	c.Colors = ColorScale(w)
+}
@@ -0,0 +1,115 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	jira "github.com/andygrunwald/go-jira/v2/onpremise"
+)
+
+const defaultIssueType = "Task"
+
+// NewIssue describes an issue to be created.
+type NewIssue struct {
+	Project string
+	// Type is the name of the issue type. Defaults to "Task".
+	Type    string
+	Summary string
+	// Description is rendered as JIRA wiki markup.
+	Description string
+	Labels      []string
+}
+
+// CreateIssue creates the given issue and returns its key.
+// The key is empty when the client runs in dry-run mode.
+func (c *Client) CreateIssue(ctx context.Context, issue NewIssue) (string, error) {
+	issueType := issue.Type
+	if issueType == "" {
+		issueType = defaultIssueType
+	}
+
+	payload := createIssuePayload{
+		Fields: createIssueFields{
+			Project:     keyRef{Key: issue.Project},
+			IssueType:   nameRef{Name: issueType},
+			Summary:     issue.Summary,
+			Description: issue.Description,
+			Labels:      issue.Labels,
+		},
+	}
+
+	var res struct {
+		Key string `json:"key"`
+	}
+
+	if err := c.write(ctx, http.MethodPost, "rest/api/2/issue", payload, &res); err != nil {
+		return "", fmt.Errorf("creating issue in %q: %w", issue.Project, err)
+	}
+
+	return res.Key, nil
+}
+
+// AddComment adds a comment rendered as JIRA wiki markup to the issue.
+func (c *Client) AddComment(ctx context.Context, key, body string) error {
+	path := "rest/api/2/issue/" + url.PathEscape(key) + "/comment"
+
+	if err := c.write(ctx, http.MethodPost, path, commentPayload{Body: body}, nil); err != nil {
+		return fmt.Errorf("commenting on %q: %w", key, err)
+	}
+
+	return nil
+}
+
+// write sends requests modifying JIRA or prints them
+// instead if the client is in dry-run mode.
+func (c *Client) write(ctx context.Context, method, path string, body, out interface{}) error {
+	if c.cfg.DryRun != nil {
+		data, err := json.MarshalIndent(body, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshalling payload: %w", err)
+		}
+
+		_, err = fmt.Fprintf(c.cfg.DryRun, "%s %s\n%s\n", method, path, data)
+
+		return err
+	}
+
+	req, err := c.c.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.c.Do(req, out)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+
+	return nil
+}
+
+type createIssuePayload struct {
+	Fields createIssueFields `json:"fields"`
+}
+
+type createIssueFields struct {
+	Project     keyRef   `json:"project"`
+	IssueType   nameRef  `json:"issuetype"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+type keyRef struct {
+	Key string `json:"key"`
+}
+
+type nameRef struct {
+	Name string `json:"name"`
+}
+
+type commentPayload struct {
+	Body string `json:"body"`
+}
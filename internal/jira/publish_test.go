@@ -0,0 +1,108 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateIssue(t *testing.T) {
+	t.Parallel()
+
+	var payload map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]string{"id": "1", "key": "SDE-42"})
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.Client(), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	key, err := c.CreateIssue(context.Background(), NewIssue{
+		Project:     "SDE",
+		Summary:     "Weekly Status - Week 4",
+		Description: "h1. Status",
+		Labels:      []string{"weekly-status"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "SDE-42", key)
+	assert.Equal(t, map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]interface{}{"key": "SDE"},
+			"issuetype":   map[string]interface{}{"name": "Task"},
+			"summary":     "Weekly Status - Week 4",
+			"description": "h1. Status",
+			"labels":      []interface{}{"weekly-status"},
+		},
+	}, payload)
+}
+
+func TestClient_AddComment(t *testing.T) {
+	t.Parallel()
+
+	var payload map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue/SDE-1/comment" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]string{"id": "1"})
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.Client(), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddComment(context.Background(), "SDE-1", "h1. Status"))
+
+	assert.Equal(t, map[string]interface{}{"body": "h1. Status"}, payload)
+}
+
+func TestClient_DryRun(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+
+	c, err := NewClient(srv.Client(), WithBaseURL(srv.URL), WithDryRun{Writer: &out})
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddComment(context.Background(), "SDE-1", "h1. Status"))
+
+	assert.Equal(t, "POST rest/api/2/issue/SDE-1/comment\n{\n  \"body\": \"h1. Status\"\n}\n", out.String())
+}
@@ -0,0 +1,41 @@
+package jira
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedTransport delays outgoing requests so that
+// they conform to the rate allowed by the given limiter.
+type rateLimitedTransport struct {
+	limiter *rate.Limiter
+	next    http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// wrapTransport returns a shallow copy of client with its
+// transport wrapped by the given function so that the
+// caller's client is never mutated.
+func wrapTransport(client *http.Client, wrap func(http.RoundTripper) http.RoundTripper) *http.Client {
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	wrapped := *client
+	wrapped.Transport = wrap(next)
+
+	return &wrapped
+}
@@ -0,0 +1,182 @@
+package jira
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// replayBaseURL is used when replaying without a configured
+// base URL as recordings do not depend on the JIRA server.
+const replayBaseURL = "https://jira.invalid/"
+
+var ErrNoRecording = errors.New("no recording")
+
+// recording is a single JIRA response stored by recordingTransport.
+// Request headers are never stored so that recordings do not
+// contain credentials.
+type recording struct {
+	Method string `json:"method"`
+	// URL is the endpoint relative to the JIRA server
+	// including the query, e.g. "rest/api/2/search?jql=...".
+	URL         string `json:"url"`
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	// Body holds JSON responses, Text all others.
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+// recordingTransport stores every response received from JIRA
+// in dir so that it can be served by replayTransport later on.
+type recordingTransport struct {
+	dir  string
+	next http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, err := recordingName(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := recording{
+		Method:      req.Method,
+		URL:         relativeURL(req),
+		Status:      res.StatusCode,
+		ContentType: res.Header.Get("Content-Type"),
+	}
+
+	if json.Valid(body) {
+		rec.Body = body
+	} else {
+		rec.Text = string(body)
+	}
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshalling recording: %w", err)
+	}
+
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating recording directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(t.dir, name), append(data, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("writing recording: %w", err)
+	}
+
+	return res, nil
+}
+
+// replayTransport serves responses stored by recordingTransport
+// and never sends requests to JIRA.
+type replayTransport struct {
+	dir string
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, err := recordingName(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	data, err := os.ReadFile(filepath.Join(t.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w of %s %s in %q", ErrNoRecording, req.Method, relativeURL(req), t.dir)
+	} else if err != nil {
+		return nil, fmt.Errorf("reading recording: %w", err)
+	}
+
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("unmarshalling recording %q: %w", name, err)
+	}
+
+	body := []byte(rec.Text)
+	if len(rec.Body) > 0 {
+		body = rec.Body
+	}
+
+	header := http.Header{}
+	if rec.ContentType != "" {
+		header.Set("Content-Type", rec.ContentType)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// recordingName identifies a request by its endpoint, query and
+// body independent of the JIRA server it is sent to, e.g.
+// "rest_api_2_search-1a2b3c4d5e6f.json".
+func recordingName(req *http.Request) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + relativeURL(req) + "\n"))
+
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", fmt.Errorf("reading request body: %w", err)
+		}
+		defer body.Close()
+
+		if _, err := io.Copy(h, body); err != nil {
+			return "", fmt.Errorf("reading request body: %w", err)
+		}
+	}
+
+	endpoint := strings.NewReplacer("/", "_", "{", "", "}", "").Replace(endpointOf(req.URL.Path))
+
+	return endpoint + "-" + hex.EncodeToString(h.Sum(nil))[:12] + ".json", nil
+}
+
+// relativeURL strips the JIRA server and its context path.
+func relativeURL(req *http.Request) string {
+	u := req.URL.EscapedPath()
+	if i := strings.Index(u, "rest/"); i >= 0 {
+		u = u[i:]
+	}
+
+	if req.URL.RawQuery != "" {
+		u += "?" + req.URL.RawQuery
+	}
+
+	return u
+}
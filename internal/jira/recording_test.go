@@ -0,0 +1,77 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RecordReplay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fields := WithFields{"storyPoints": {Name: "Story Points"}}
+
+	srv := httptest.NewServer(http.StripPrefix("/jira", &fakeJIRA{
+		Total:         3,
+		TruncatedKeys: map[string]bool{"TEST-2": true},
+		Comments:      true,
+		Fields: []map[string]interface{}{
+			{"id": "customfield_2", "name": "Story Points"},
+		},
+	}))
+
+	recorder, err := NewClient(srv.Client(), WithBaseURL(srv.URL+"/jira"), WithRecordDir(dir), fields)
+	require.NoError(t, err)
+
+	recorded, err := recorder.SearchIssues(context.Background(), "project = TEST")
+	require.NoError(t, err)
+
+	// Replaying must not depend on the server.
+	srv.Close()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "field list, search and truncated issue are recorded")
+
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), srv.URL, "recordings are independent of the server")
+	}
+
+	replayer, err := NewClient(nil, WithReplayDir(dir), fields)
+	require.NoError(t, err)
+
+	replayed, err := replayer.SearchIssues(context.Background(), "project = TEST")
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+
+	_, err = replayer.SearchIssues(context.Background(), "project = OTHER")
+	require.ErrorIs(t, err, ErrNoRecording)
+}
+
+// TestClient_Replay renders issues from recordings checked in to
+// testdata/recordings, which were recorded with WithRecordDir.
+func TestClient_Replay(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewClient(nil, WithReplayDir(filepath.Join("testdata", "recordings")))
+	require.NoError(t, err)
+
+	issues, err := c.SearchIssues(context.Background(), `project = SDE AND labels = "nasa"`)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	assert.Equal(t, "SDE-1", issues[0].Key)
+	assert.Equal(t, ColorRed, issues[0].Color)
+	assert.Equal(t, "SDE-2", issues[1].Key)
+	assert.True(t, strings.HasPrefix(issues[1].StatusComment, "latest"), "truncated comments are fetched individually")
+}
@@ -0,0 +1,182 @@
+package jira
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 4
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 30 * time.Second
+)
+
+// RetryConfig configures retries of failed JIRA requests.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts per request
+	// including the initial one.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry.
+	// It doubles with every further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff between two attempts.
+	// Requests are not retried if the server asks
+	// to wait longer through the Retry-After header.
+	MaxDelay time.Duration
+}
+
+func (c *RetryConfig) Default() {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultRetryMaxAttempts
+	}
+
+	if c.BaseDelay <= 0 {
+		c.BaseDelay = defaultRetryBaseDelay
+	}
+
+	if c.MaxDelay <= 0 {
+		c.MaxDelay = defaultRetryMaxDelay
+	}
+}
+
+// retryTransport retries idempotent requests which failed
+// with a transient error using exponential backoff with
+// full jitter, unless the server asks for a specific delay
+// through the Retry-After header. Delays exceeding MaxDelay
+// are not waited for and the response is returned instead.
+type retryTransport struct {
+	cfg  RetryConfig
+	next http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+
+	for attempt := 1; ; attempt++ {
+		res, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.cfg.MaxAttempts || !isRetryable(req, res, err) {
+			return res, err
+		}
+
+		delay := t.backoff(attempt)
+		if d, ok := retryAfter(res); ok {
+			if d > t.cfg.MaxDelay {
+				return res, err
+			}
+
+			delay = d
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		attemptReq, err = rewind(req)
+		if err != nil {
+			return nil, err
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	ceil := t.cfg.BaseDelay << (attempt - 1)
+	if ceil <= 0 || ceil > t.cfg.MaxDelay {
+		ceil = t.cfg.MaxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(ceil) + 1)) //nolint:gosec
+}
+
+func isRetryable(req *http.Request, res *http.Response, err error) bool {
+	if !isIdempotent(req) {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return req.Header.Get("Idempotency-Key") != ""
+	}
+}
+
+// rewind returns a copy of req with a fresh body so
+// that the original request is never modified.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = body
+
+	return clone, nil
+}
+
+// retryAfter parses the Retry-After header of res which
+// may either hold a number of seconds or an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	raw := res.Header.Get("Retry-After")
+	if raw == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(raw); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(raw); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,223 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Method         string
+		Schedule       []scriptedResponse
+		MaxAttempts    int
+		ExpectedStatus int
+		ExpectedCalls  int
+	}{
+		"succeeds without retry": {
+			Schedule:       []scriptedResponse{{Status: http.StatusOK}},
+			ExpectedStatus: http.StatusOK,
+			ExpectedCalls:  1,
+		},
+		"retries transient errors": {
+			Schedule: []scriptedResponse{
+				{Status: http.StatusBadGateway},
+				{Status: http.StatusServiceUnavailable},
+				{Status: http.StatusOK},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedCalls:  3,
+		},
+		"honours Retry-After": {
+			Schedule: []scriptedResponse{
+				{Status: http.StatusTooManyRequests, RetryAfter: "0"},
+				{Status: http.StatusOK},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedCalls:  2,
+		},
+		"gives up if Retry-After exceeds MaxDelay": {
+			Schedule: []scriptedResponse{
+				{Status: http.StatusTooManyRequests, RetryAfter: "3600"},
+				{Status: http.StatusOK},
+			},
+			ExpectedStatus: http.StatusTooManyRequests,
+			ExpectedCalls:  1,
+		},
+		"retries connection resets": {
+			Schedule: []scriptedResponse{
+				{Reset: true},
+				{Status: http.StatusOK},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedCalls:  2,
+		},
+		"gives up after attempt budget": {
+			Schedule: []scriptedResponse{
+				{Status: http.StatusBadGateway},
+				{Status: http.StatusBadGateway},
+				{Status: http.StatusBadGateway},
+			},
+			MaxAttempts:    2,
+			ExpectedStatus: http.StatusBadGateway,
+			ExpectedCalls:  2,
+		},
+		"does not retry client errors": {
+			Schedule: []scriptedResponse{
+				{Status: http.StatusNotFound},
+				{Status: http.StatusOK},
+			},
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedCalls:  1,
+		},
+		"does not retry non-idempotent requests": {
+			Method: http.MethodPost,
+			Schedule: []scriptedResponse{
+				{Status: http.StatusServiceUnavailable},
+				{Status: http.StatusOK},
+			},
+			ExpectedStatus: http.StatusServiceUnavailable,
+			ExpectedCalls:  1,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newScriptedServer(tc.Schedule)
+			defer srv.Close()
+
+			cfg := RetryConfig{
+				MaxAttempts: tc.MaxAttempts,
+				BaseDelay:   time.Millisecond,
+				MaxDelay:    5 * time.Millisecond,
+			}
+			cfg.Default()
+
+			client := wrapTransport(srv.Client(), func(next http.RoundTripper) http.RoundTripper {
+				return &retryTransport{cfg: cfg, next: next}
+			})
+
+			method := tc.Method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			var body *strings.Reader
+			if method == http.MethodPost {
+				body = strings.NewReader("{}")
+			} else {
+				body = strings.NewReader("")
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), method, srv.URL, body)
+			require.NoError(t, err)
+
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.ExpectedStatus, res.StatusCode)
+
+			assert.Equal(t, tc.ExpectedCalls, srv.Calls())
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Header   string
+		Expected time.Duration
+		OK       bool
+	}{
+		"missing":   {},
+		"seconds":   {Header: "3", Expected: 3 * time.Second, OK: true},
+		"past date": {Header: "Mon, 02 Jan 2006 15:04:05 GMT", OK: true},
+		"invalid":   {Header: "soon"},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := &http.Response{Header: http.Header{}}
+			if tc.Header != "" {
+				res.Header.Set("Retry-After", tc.Header)
+			}
+
+			d, ok := retryAfter(res)
+			assert.Equal(t, tc.OK, ok)
+			assert.Equal(t, tc.Expected, d)
+		})
+	}
+}
+
+type scriptedResponse struct {
+	Status     int
+	RetryAfter string
+	// Reset closes the connection without responding.
+	Reset bool
+}
+
+type scriptedServer struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	schedule []scriptedResponse
+	calls    int
+}
+
+// newScriptedServer returns a server answering each request
+// with the next entry of schedule, repeating the last one
+// once the schedule is exhausted.
+func newScriptedServer(schedule []scriptedResponse) *scriptedServer {
+	s := &scriptedServer{schedule: schedule}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+func (s *scriptedServer) Calls() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.calls
+}
+
+func (s *scriptedServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	i := s.calls
+	if i >= len(s.schedule) {
+		i = len(s.schedule) - 1
+	}
+	resp := s.schedule[i]
+	s.calls++
+	s.lock.Unlock()
+
+	if resp.Reset {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+
+		return
+	}
+
+	if resp.RetryAfter != "" {
+		w.Header().Set("Retry-After", resp.RetryAfter)
+	}
+
+	w.WriteHeader(resp.Status)
+}